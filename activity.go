@@ -0,0 +1,445 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ==================== ممبر ایکٹیویٹی ====================
+// ہر گروپ میں ہر ممبر کے میسجز، میڈیا اور آخری بار دیکھے جانے کا وقت (ریڈیس)
+// .top لیڈر بورڈ، .inactive لسٹ، .prune تصدیق کے بعد بیچز میں ریموو
+
+const (
+	activityPrefix      = "activity:"
+	activityDedupTTL    = 10 * time.Minute // ایک گروپ میں کئی بوٹس ہوں تو ایک میسج ایک بار
+	activityDayTTL      = 48 * time.Hour
+	activityWeekTTL     = 8 * 24 * time.Hour
+	pruneConfirmTTL     = 2 * time.Minute
+	pruneBatchSize      = 5
+	pruneBatchDelay     = 4 * time.Second
+	activityTopLimit    = 10
+	activityListMaxShow = 40
+)
+
+func activityKey(kind, chat string) string {
+	return activityPrefix + kind + ":" + chat
+}
+
+func activityDayKey(chat string, t time.Time) string {
+	return activityKey("day", chat) + ":" + t.Format("20060102")
+}
+
+func activityWeekKey(chat string, t time.Time) string {
+	y, w := t.ISOWeek()
+	return activityKey("week", chat) + fmt.Sprintf(":%d-W%02d", y, w)
+}
+
+// 🆔 ممبر کی مستقل آئی ڈی (نمبر ملے تو نمبر، ورنہ LID)
+func activityUserID(client *whatsmeow.Client, user types.JID) string {
+	if user.Server == types.HiddenUserServer {
+		if phone := resolvePhoneNumber(client, user); phone != "" {
+			return phone
+		}
+	}
+	return getCleanID(user.User)
+}
+
+func isMediaMessage(m *waProto.Message) bool {
+	return m.GetImageMessage() != nil || m.GetVideoMessage() != nil || m.GetAudioMessage() != nil ||
+		m.GetStickerMessage() != nil || m.GetDocumentMessage() != nil
+}
+
+// 📊 آنے والے گروپ میسج کو گنیں (processMessage سے)
+func trackActivity(client *whatsmeow.Client, v *events.Message) {
+	if rdb == nil || !v.Info.IsGroup || v.Info.IsFromMe {
+		return
+	}
+	chat := v.Info.Chat.String()
+	if ok, err := rdb.SetNX(ctx, activityPrefix+"dedup:"+chat+":"+v.Info.ID, 1, activityDedupTTL).Result(); err != nil || !ok {
+		return
+	}
+
+	now := time.Now()
+	user := activityUserID(client, v.Info.Sender)
+	dayKey, weekKey := activityDayKey(chat, now), activityWeekKey(chat, now)
+
+	pipe := rdb.Pipeline()
+	pipe.SetNX(ctx, activityKey("since", chat), now.Unix(), 0)
+	pipe.ZIncrBy(ctx, activityKey("all", chat), 1, user)
+	pipe.ZIncrBy(ctx, dayKey, 1, user)
+	pipe.Expire(ctx, dayKey, activityDayTTL)
+	pipe.ZIncrBy(ctx, weekKey, 1, user)
+	pipe.Expire(ctx, weekKey, activityWeekTTL)
+	if isMediaMessage(v.Message) {
+		pipe.HIncrBy(ctx, activityKey("media", chat), user, 1)
+	}
+	// آخری بار: تمام آئی ڈیز پر (LID اور نمبر دونوں)
+	for _, id := range append(userIDVariants(client, v.Info.Sender), user) {
+		pipe.HSet(ctx, activityKey("seen", chat), id, now.Unix())
+	}
+	pipe.Exec(ctx)
+}
+
+// 👋 نئے ممبر کا "آخری بار" جوائن کا وقت، تاکہ فوراً خاموش شمار نہ ہو
+func markActivityJoins(client *whatsmeow.Client, v *events.GroupInfo) {
+	if rdb == nil || len(v.Join) == 0 {
+		return
+	}
+	now := time.Now().Unix()
+	pipe := rdb.Pipeline()
+	for _, u := range v.Join {
+		for _, id := range userIDVariants(client, u) {
+			pipe.HSet(ctx, activityKey("seen", v.JID.String()), id, now)
+		}
+	}
+	pipe.Exec(ctx)
+}
+
+// ⏱️ "30d", "2w", "12h" یا صرف نمبر (دن)
+func parseActivityPeriod(arg string) (time.Duration, error) {
+	arg = strings.ToLower(strings.TrimSpace(arg))
+	if arg == "" {
+		return 0, fmt.Errorf("missing period")
+	}
+	unit := time.Duration(24) * time.Hour
+	switch arg[len(arg)-1] {
+	case 'd':
+		arg = arg[:len(arg)-1]
+	case 'w':
+		unit *= 7
+		arg = arg[:len(arg)-1]
+	case 'h':
+		unit = time.Hour
+		arg = arg[:len(arg)-1]
+	}
+	n, err := strconv.Atoi(arg)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid period")
+	}
+	d := time.Duration(n) * unit
+	if d < 24*time.Hour {
+		return 0, fmt.Errorf("minimum is 1d")
+	}
+	return d, nil
+}
+
+func periodText(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+	return d.String()
+}
+
+// InactiveMember ایک خاموش ممبر
+type InactiveMember struct {
+	Member   RosterMember
+	LastSeen time.Time // صفر = ٹریکنگ شروع ہونے کے بعد کبھی نہیں بولا
+}
+
+// 💤 N دن سے خاموش ممبرز (ایڈمنز، اونر اور بوٹ کے علاوہ)
+// trackedEnough = false → ٹریکنگ ابھی N دن پرانی نہیں، کبھی نہ بولنے والے شامل نہیں
+func findInactiveMembers(client *whatsmeow.Client, chat types.JID, period time.Duration) (list []InactiveMember, trackedEnough bool, since time.Time) {
+	if rdb == nil {
+		return nil, false, since
+	}
+	chatID := chat.String()
+	cutoff := time.Now().Add(-period)
+	if ts, err := rdb.Get(ctx, activityKey("since", chatID)).Int64(); err == nil {
+		since = time.Unix(ts, 0)
+		trackedEnough = !since.After(cutoff)
+	}
+	seen, _ := rdb.HGetAll(ctx, activityKey("seen", chatID)).Result()
+
+	botIDs := map[string]bool{}
+	if client.Store.ID != nil {
+		botIDs[getCleanID(client.Store.ID.User)] = true
+	}
+	if !client.Store.LID.IsEmpty() {
+		botIDs[getCleanID(client.Store.LID.User)] = true
+	}
+
+	for _, m := range groupMembers(client, chat) {
+		ids := []string{m.Phone, m.LID, getCleanID(m.JID.User)}
+		if m.IsAdmin || m.IsSuperAdmin || isOwner(client, m.JID) || botIDs[m.Phone] || botIDs[m.LID] || botIDs[getCleanID(m.JID.User)] {
+			continue
+		}
+		var last int64
+		for _, id := range ids {
+			if id == "" {
+				continue
+			}
+			if ts, err := strconv.ParseInt(seen[id], 10, 64); err == nil && ts > last {
+				last = ts
+			}
+		}
+		if last == 0 {
+			if trackedEnough {
+				list = append(list, InactiveMember{Member: m})
+			}
+			continue
+		}
+		if t := time.Unix(last, 0); t.Before(cutoff) {
+			list = append(list, InactiveMember{Member: m, LastSeen: t})
+		}
+	}
+	return list, trackedEnough, since
+}
+
+func inactiveLine(im InactiveMember) string {
+	last := "never"
+	if !im.LastSeen.IsZero() {
+		last = fmt.Sprintf("%dd ago", int(time.Since(im.LastSeen)/(24*time.Hour)))
+	}
+	return fmt.Sprintf("║ @%s — %s", im.Member.JID.User, last)
+}
+
+// ==================== .top [day|week|all] ====================
+func handleTop(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "⚠️ Redis not connected.")
+		return
+	}
+	chat := v.Info.Chat.String()
+	period := "week"
+	if len(args) > 0 {
+		period = strings.ToLower(args[0])
+	}
+
+	var key, title string
+	now := time.Now()
+	switch period {
+	case "day", "today":
+		key, title = activityDayKey(chat, now), "TODAY"
+	case "week":
+		key, title = activityWeekKey(chat, now), "THIS WEEK"
+	case "all":
+		key, title = activityKey("all", chat), "ALL TIME"
+	default:
+		replyMessage(client, v, "⚠️ Usage: .top day / week / all")
+		return
+	}
+
+	top, err := rdb.ZRevRangeWithScores(ctx, key, 0, activityTopLimit-1).Result()
+	if err != nil || len(top) == 0 {
+		replyMessage(client, v, "📊 No activity recorded for this period yet.")
+		return
+	}
+	media, _ := rdb.HGetAll(ctx, activityKey("media", chat)).Result()
+
+	medals := []string{"🥇", "🥈", "🥉"}
+	out := fmt.Sprintf("╔════════════════╗\n║ 🏆 TOP MEMBERS — %s\n╠════════════════╣\n", title)
+	for i, z := range top {
+		id, _ := z.Member.(string)
+		rank := fmt.Sprintf("%d.", i+1)
+		if i < len(medals) {
+			rank = medals[i]
+		}
+		name := displayName(client, types.NewJID(id, types.DefaultUserServer))
+		line := fmt.Sprintf("║ %s %s — %d msgs", rank, name, int(z.Score))
+		if period == "all" && media[id] != "" && media[id] != "0" {
+			line += " | " + media[id] + " media"
+		}
+		out += line + "\n"
+	}
+	if ts, err := rdb.Get(ctx, activityKey("since", chat)).Int64(); err == nil {
+		out += "╠════════════════╣\n║ 📅 Tracking since " + time.Unix(ts, 0).Format("02 Jan 2006") + "\n"
+	}
+	out += "╚════════════════╝"
+	replyMessage(client, v, out)
+}
+
+// ==================== .inactive 30d ====================
+func handleInactive(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+	arg := "30d"
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	period, err := parseActivityPeriod(arg)
+	if err != nil {
+		replyMessage(client, v, "⚠️ Usage: .inactive 30d ("+err.Error()+")")
+		return
+	}
+
+	list, trackedEnough, since := findInactiveMembers(client, v.Info.Chat, period)
+	out := fmt.Sprintf("╔════════════════╗\n║ 💤 INACTIVE %s+\n╠════════════════╣\n║ 👥 Members: %d\n", periodText(period), len(list))
+	var mentions []string
+	for i, im := range list {
+		if i == activityListMaxShow {
+			out += fmt.Sprintf("║ ... and %d more\n", len(list)-i)
+			break
+		}
+		out += inactiveLine(im) + "\n"
+		mentions = append(mentions, im.Member.JID.String())
+	}
+	if !trackedEnough {
+		note := "not started yet"
+		if !since.IsZero() {
+			note = "since " + since.Format("02 Jan 2006")
+		}
+		out += "╠════════════════╣\n║ ⚠️ Tracking " + note + "\n║ Members who never spoke are not listed yet\n"
+	}
+	out += "╠════════════════╣\n║ .prune " + periodText(period) + " → remove them\n╚════════════════╝"
+
+	client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text:        proto.String(out),
+			ContextInfo: &waProto.ContextInfo{MentionedJID: mentions, StanzaID: proto.String(v.Info.ID), Participant: proto.String(v.Info.Sender.String()), QuotedMessage: v.Message},
+		},
+	})
+}
+
+// ==================== .prune 30d / .prune confirm ====================
+func handlePrune(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "⚠️ Redis not connected.")
+		return
+	}
+	if len(args) == 0 {
+		replyMessage(client, v, "⚠️ Usage: .prune 30d → then .prune confirm")
+		return
+	}
+
+	chat := v.Info.Chat.String()
+	pendingKey := "prune:pending:" + chat
+	sender := getCleanID(v.Info.Sender.User)
+
+	switch strings.ToLower(args[0]) {
+	case "cancel":
+		rdb.Del(ctx, pendingKey)
+		replyMessage(client, v, "✅ Prune cancelled.")
+		return
+
+	case "confirm", "yes":
+		pending, err := rdb.Get(ctx, pendingKey).Result()
+		parts := strings.SplitN(pending, "|", 2)
+		if err != nil || len(parts) != 2 {
+			replyMessage(client, v, "⚠️ Nothing to confirm. Run .prune 30d first.")
+			return
+		}
+		if parts[0] != sender {
+			replyMessage(client, v, "❌ Only the admin who started this prune can confirm it.")
+			return
+		}
+		period, _ := time.ParseDuration(parts[1])
+		rdb.Del(ctx, pendingKey)
+		if !botIsGroupAdmin(client, v.Info.Chat) {
+			replyMessage(client, v, "❌ I need to be an admin to remove members.")
+			return
+		}
+		if ok, _ := rdb.SetNX(ctx, "prune:lock:"+chat, sender, time.Hour).Result(); !ok {
+			replyMessage(client, v, "⏳ A prune is already running in this group.")
+			return
+		}
+		go runPrune(client, v, period)
+		return
+	}
+
+	period, err := parseActivityPeriod(args[0])
+	if err != nil {
+		replyMessage(client, v, "⚠️ Usage: .prune 30d ("+err.Error()+")")
+		return
+	}
+	list, trackedEnough, _ := findInactiveMembers(client, v.Info.Chat, period)
+	if len(list) == 0 {
+		msg := "✅ No members have been silent for " + periodText(period) + "."
+		if !trackedEnough {
+			msg += "\n⚠️ Tracking is newer than that, so members who never spoke are not counted yet."
+		}
+		replyMessage(client, v, msg)
+		return
+	}
+
+	rdb.Set(ctx, pendingKey, sender+"|"+period.String(), pruneConfirmTTL)
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ ✂️ PRUNE PREVIEW
+╠════════════════╣
+║ 💤 Silent for: %s+
+║ 👥 Members: %d
+║ 📦 Batches of %d every %s
+╠════════════════╣
+║ .inactive %s → see the list
+║ .prune confirm → remove them
+║ .prune cancel
+║ ⏳ Expires in %s
+╚════════════════╝`, periodText(period), len(list), pruneBatchSize, pruneBatchDelay, periodText(period), pruneConfirmTTL))
+}
+
+// ✂️ بیچز میں ریموو (لسٹ دوبارہ بنتی ہے تاکہ تصدیق کے دوران بولنے والے بچ جائیں)
+func runPrune(client *whatsmeow.Client, v *events.Message, period time.Duration) {
+	chat := v.Info.Chat
+	botID := getCleanID(client.Store.ID.User)
+	actor := getCleanID(v.Info.Sender.User)
+	defer rdb.Del(ctx, "prune:lock:"+chat.String())
+
+	list, _, _ := findInactiveMembers(client, chat, period)
+	replyMessage(client, v, fmt.Sprintf("✂️ Removing %d inactive members...", len(list)))
+
+	removed, failed := 0, 0
+	for start := 0; start < len(list); start += pruneBatchSize {
+		end := min(start+pruneBatchSize, len(list))
+		var batch []types.JID
+		for _, im := range list[start:end] {
+			batch = append(batch, im.Member.JID)
+		}
+		res, err := client.UpdateGroupParticipants(context.Background(), chat, batch, whatsmeow.ParticipantChangeRemove)
+		if err != nil {
+			failed += len(batch)
+		} else {
+			for _, p := range res {
+				if p.Error != 0 {
+					failed++
+					continue
+				}
+				removed++
+				logModAction(ModLogEntry{
+					BotID: botID, GroupID: chat.String(), Actor: actor, Target: getCleanID(p.JID.User),
+					Action: "kick", Rule: "prune", Reason: "Inactive for " + periodText(period),
+				})
+			}
+		}
+		// 📈 ہر 5 بیچز پر پروگریس
+		if (start/pruneBatchSize+1)%5 == 0 && end < len(list) {
+			sendPlainText(client, chat, fmt.Sprintf("✂️ Prune progress: %d/%d", end, len(list)))
+		}
+		if end < len(list) {
+			time.Sleep(pruneBatchDelay)
+		}
+	}
+
+	sendPlainText(client, chat, fmt.Sprintf(`╔════════════════╗
+║ ✂️ PRUNE DONE
+╠════════════════╣
+║ ✅ Removed: %d
+║ ❌ Failed: %d
+║ 💤 Inactive: %s+
+╚════════════════╝`, removed, failed, periodText(period)))
+}
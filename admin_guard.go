@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ==================== اینٹی ٹیک اوور (ایڈمن گارڈ) ====================
+// کوئی غیر محفوظ (unprotected) ایڈمن کسی کو پروموٹ/ڈیموٹ کرے تو بوٹ اسے واپس پلٹ دیتا ہے
+// اور گروپ کریئٹر + بوٹ اونر کو الرٹ کرتا ہے (ہیک شدہ ایڈمن اکاؤنٹ سے بچاؤ)
+
+const adminGuardLockPrefix = "adminguard:lock:"
+
+// کیا یہ ایکٹر تبدیلی کا مجاز ہے؟ (اس ڈیپلائمنٹ کا کوئی بھی بوٹ، گروپ کریئٹر، یا پروٹیکٹڈ لسٹ)
+// دوسرے بوٹ کا ریورٹ بھی مجاز، ورنہ دو بوٹس ایک دوسرے کو پلٹتے رہیں گے
+func adminGuardAuthorized(client *whatsmeow.Client, s *GroupSettings, info *types.GroupInfo, actor types.JID) bool {
+	if isDeploymentBot(actor) {
+		return true
+	}
+	ids := userIDVariants(client, actor)
+	for _, id := range ids {
+		if info != nil && (id == getCleanID(info.OwnerJID.User) || id == getCleanID(info.OwnerPN.User)) {
+			return true
+		}
+		for _, p := range s.AdminGuard.Protected {
+			if id == p {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// 🛡️ GroupInfo کے Promote/Demote پر
+func enforceAdminGuard(client *whatsmeow.Client, botID string, s *GroupSettings, v *events.GroupInfo) {
+	if v.Sender == nil || v.Sender.IsEmpty() {
+		return
+	}
+	actor := *v.Sender
+	bg := context.Background()
+
+	info, err := client.GetGroupInfo(bg, v.JID)
+	if err != nil {
+		info = nil
+	}
+	if adminGuardAuthorized(client, s, info, actor) {
+		return
+	}
+
+	// کئی بوٹس ہوں تو ایک ہی پلٹے
+	lock := fmt.Sprintf("%s%s:%s:%d", adminGuardLockPrefix, v.JID.String(), getCleanID(actor.User), v.Timestamp.Unix())
+	if rdb != nil {
+		if ok, err := rdb.SetNX(ctx, lock, botID, time.Minute).Result(); err != nil || !ok {
+			return
+		}
+	}
+	if !botIsGroupAdmin(client, v.JID) {
+		if rdb != nil {
+			rdb.Del(ctx, lock)
+		}
+		return
+	}
+
+	var reverted []string
+	revert := func(users []types.JID, change whatsmeow.ParticipantChange, action string) {
+		var targets []types.JID
+		for _, u := range users {
+			// بوٹ کو خود ڈیموٹ نہ کرے
+			if client.Store.ID != nil && u.User == client.Store.ID.User {
+				continue
+			}
+			targets = append(targets, u)
+		}
+		if len(targets) == 0 {
+			return
+		}
+		if _, err := client.UpdateGroupParticipants(bg, v.JID, targets, change); err != nil {
+			fmt.Printf("⚠️ [ADMINGUARD] Revert %s failed in %s: %v\n", action, v.JID, err)
+			return
+		}
+		for _, u := range targets {
+			reverted = append(reverted, fmt.Sprintf("%s @%s", action, u.User))
+			logModAction(ModLogEntry{
+				BotID:   botID,
+				GroupID: v.JID.String(),
+				Actor:   "bot",
+				Target:  getCleanID(u.User),
+				Action:  action,
+				Rule:    "adminguard",
+				Reason:  "Reverted change by unprotected admin " + getCleanID(actor.User),
+				Auto:    true,
+			})
+		}
+	}
+	revert(v.Promote, whatsmeow.ParticipantChangeDemote, "demote")
+	revert(v.Demote, whatsmeow.ParticipantChangePromote, "promote")
+
+	if len(reverted) == 0 {
+		return
+	}
+
+	alert := fmt.Sprintf(`╔════════════════╗
+║ 🛡️ ADMIN GUARD
+╠════════════════╣
+║ ⚠️ Unauthorized admin change
+║ 👤 By: @%s
+║ ↩️ Reverted:
+║   %s
+╚════════════════╝`, actor.User, strings.Join(reverted, "\n║   "))
+
+	mentions := []string{actor.String()}
+	for _, u := range append(append([]types.JID{}, v.Promote...), v.Demote...) {
+		mentions = append(mentions, u.String())
+	}
+	client.SendMessage(bg, v.JID, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text:        proto.String(alert),
+			ContextInfo: &waProto.ContextInfo{MentionedJID: mentions},
+		},
+	})
+
+	// 📩 گروپ کریئٹر اور بوٹ اونر کو DM
+	groupName := v.JID.String()
+	if info != nil {
+		groupName = info.Name
+	}
+	dm := alert + "\n📍 Group: " + groupName
+	if info != nil && !info.OwnerJID.IsEmpty() {
+		sendPlainText(client, info.OwnerJID.ToNonAD(), dm)
+	}
+	if client.Store.ID != nil {
+		sendPlainText(client, client.Store.ID.ToNonAD(), dm)
+	}
+}
+
+// 👑 گارڈ سیٹنگز صرف گروپ کریئٹر یا بوٹ اونر بدل سکتا ہے (ہیک شدہ ایڈمن اسے بند نہ کر سکے)
+func isGroupCreator(client *whatsmeow.Client, chat, user types.JID) bool {
+	info, err := client.GetGroupInfo(context.Background(), chat)
+	if err != nil {
+		return false
+	}
+	for _, id := range userIDVariants(client, user) {
+		if id == getCleanID(info.OwnerJID.User) || id == getCleanID(info.OwnerPN.User) {
+			return true
+		}
+	}
+	for _, p := range info.Participants {
+		if p.IsSuperAdmin && getCleanID(p.JID.User) == getCleanID(user.User) {
+			return true
+		}
+	}
+	return false
+}
+
+// ==================== .adminguard ====================
+func handleAdminGuard(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isOwner(client, v.Info.Sender) && !isGroupCreator(client, v.Info.Chat, v.Info.Sender) {
+		replyMessage(client, v, "👑 Only the Group Creator or Bot Owner can manage Admin Guard.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	switch sub {
+	case "on":
+		s.AdminGuard.Enabled = true
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "✅ Admin Guard ON. Promote/Demote by unprotected admins will be reverted.")
+
+	case "off":
+		s.AdminGuard.Enabled = false
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "❌ Admin Guard OFF.")
+
+	case "protect", "unprotect":
+		target, _ := extractTargetJID(v, args[1:])
+		if target.IsEmpty() {
+			replyMessage(client, v, fmt.Sprintf("⚠️ Usage: .adminguard %s @user", sub))
+			return
+		}
+		ids := userIDVariants(client, target)
+		var kept []string
+		for _, p := range s.AdminGuard.Protected {
+			match := false
+			for _, id := range ids {
+				if p == id {
+					match = true
+				}
+			}
+			if !match {
+				kept = append(kept, p)
+			}
+		}
+		if sub == "protect" {
+			kept = append(kept, ids[0])
+		}
+		s.AdminGuard.Protected = kept
+		saveGroupSettings(botID, s)
+		label := "Protected"
+		if sub == "unprotect" {
+			label = "Unprotected"
+		}
+		replyMessage(client, v, fmt.Sprintf("✅ %s: %s", label, ids[0]))
+
+	default:
+		status := "🔴 OFF"
+		if s.AdminGuard.Enabled {
+			status = "🟢 ON"
+		}
+		list := "║ (none — only creator & bot)\n"
+		if len(s.AdminGuard.Protected) > 0 {
+			list = ""
+			for i, p := range s.AdminGuard.Protected {
+				list += fmt.Sprintf("║ %d. %s\n", i+1, p)
+			}
+		}
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🛡️ ADMIN GUARD
+╠════════════════╣
+║ Status: %s
+║ Protected Admins:
+%s╠════════════════╣
+║ .adminguard on/off
+║ .adminguard protect @user
+║ .adminguard unprotect @user
+╚════════════════╝`, status, list))
+	}
+}
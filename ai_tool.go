@@ -83,6 +83,7 @@ func handleServerStats(client *whatsmeow.Client, v *events.Message) {
 	sys := m.Sys / 1024 / 1024
 	numCPU := runtime.NumCPU()
 	goRoutines := runtime.NumGoroutine()
+	cachedGroups, cachedMembers := rosterStats()
 
 	stats := fmt.Sprintf(`╔══════════════════════╗
 ║     🖥️ SYSTEM DASHBOARD    
@@ -92,8 +93,9 @@ func handleServerStats(client *whatsmeow.Client, v *events.Message) {
 ║ 🧬 System Memory: %d MB
 ║ 🧠 CPU Cores: %d
 ║ 🧵 Active Threads: %d
+║ 👥 Cached Groups: %d (%d members)
 ║ 🟢 Status: Invincible
-╚══════════════════════╝`, used, sys, numCPU, goRoutines)
+╚══════════════════════╝`, used, sys, numCPU, goRoutines, cachedGroups, cachedMembers)
 	replyMessage(client, v, stats)
 }
 
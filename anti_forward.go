@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ==================== اینٹی فارورڈ ====================
+// چین میسجز اور اسکیمز عموماً فارورڈ ہو کر آتے ہیں
+// ContextInfo.IsForwarded / ForwardingScore سے پہچان (ٹیکسٹ، تصویر، ویڈیو، ڈاکیومنٹ)
+
+// واٹس ایپ 5 یا زیادہ پر "Forwarded many times" دکھاتا ہے
+const frequentlyForwardedScore = 5
+
+// میسج کی ContextInfo (صرف وہ ٹائپس جن پر رول لگتا ہے)
+func forwardContextInfo(m *waProto.Message) *waProto.ContextInfo {
+	if m == nil {
+		return nil
+	}
+	switch {
+	case m.ExtendedTextMessage != nil:
+		return m.ExtendedTextMessage.GetContextInfo()
+	case m.ImageMessage != nil:
+		return m.ImageMessage.GetContextInfo()
+	case m.VideoMessage != nil:
+		return m.VideoMessage.GetContextInfo()
+	case m.DocumentMessage != nil:
+		return m.DocumentMessage.GetContextInfo()
+	}
+	return nil
+}
+
+// کیا میسج فارورڈ شدہ ہے؟ (اسکور کے ساتھ)
+func isForwardedMessage(v *events.Message) (bool, int) {
+	ci := forwardContextInfo(v.Message)
+	if ci == nil {
+		return false, 0
+	}
+	score := int(ci.GetForwardingScore())
+	return ci.GetIsForwarded() || score > 0, score
+}
+
+// ⚡ کیا اس گروپ کا رول اس میسج پر لاگو ہوتا ہے؟
+func matchAntiForward(s *GroupSettings, v *events.Message) (bool, string) {
+	if !s.AntiForward.Enabled {
+		return false, ""
+	}
+	forwarded, score := isForwardedMessage(v)
+	if !forwarded || score < s.AntiForward.MinScore {
+		return false, ""
+	}
+	if score >= frequentlyForwardedScore {
+		return true, fmt.Sprintf("Forwarded many times (score %d)", score)
+	}
+	return true, "Forwarded message not allowed"
+}
+
+// کارڈز کے لیے تھریشولڈ کی وضاحت
+func antiForwardThresholdText(minScore int) string {
+	if minScore <= 0 {
+		return "All forwards"
+	}
+	return fmt.Sprintf("Score ≥ %d", minScore)
+}
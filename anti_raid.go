@@ -0,0 +1,393 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ==================== اینٹی ریڈ ====================
+// لیک شدہ لنک سے ایک دم بہت سے جوائنز → گروپ لاک، لنک ری سیٹ، (آپشنل) جوائنرز کک، ایڈمنز کو الرٹ
+// لاک ڈاؤن ریڈیس کی میں شیئرڈ ہے تاکہ گروپ کے کئی بوٹس میں سے ایک ہی ایکشن لے
+
+const (
+	raidActivePrefix     = "raid:active:"
+	raidUnlockKey        = "raid:unlock"      // zset: chatID → ان لاک کا وقت (unix)
+	raidUnlockLockPrefix = "raid:unlocklock:" // ایک وقت میں ایک ہی بوٹ ان لاک کرے
+	raidUnlockTick       = 30 * time.Second
+	defaultRaidThreshold = 10
+	defaultRaidWindow    = 60 // سیکنڈ
+	defaultRaidCooldown  = 30 // منٹ
+	raidKickBatch        = 5
+)
+
+type raidJoin struct {
+	JID types.JID
+	At  time.Time
+}
+
+var (
+	raidJoins   = make(map[string][]raidJoin) // botID:chatID → حالیہ جوائنز
+	raidJoinsMu sync.Mutex
+)
+
+// سیٹنگز میں خالی ویلیوز کے ڈیفالٹس
+func raidLimits(r AntiRaidRule) (threshold int, window, cooldown time.Duration) {
+	threshold, w, c := r.Threshold, r.WindowSec, r.CooldownMin
+	if threshold <= 0 {
+		threshold = defaultRaidThreshold
+	}
+	if w <= 0 {
+		w = defaultRaidWindow
+	}
+	if c <= 0 {
+		c = defaultRaidCooldown
+	}
+	return threshold, time.Duration(w) * time.Second, time.Duration(c) * time.Minute
+}
+
+func isRaidLockdown(chatID string) bool {
+	if rdb == nil {
+		return false
+	}
+	n, _ := rdb.Exists(ctx, raidActivePrefix+chatID).Result()
+	return n > 0
+}
+
+// 📈 GroupInfo کے ہر Join پر
+func trackRaidJoins(client *whatsmeow.Client, botID string, s *GroupSettings, v *events.GroupInfo) {
+	chatID := v.JID.String()
+	threshold, window, cooldown := raidLimits(s.AntiRaid)
+	now := time.Now()
+
+	// لاک ڈاؤن کے دوران آنے والے بھی (اگر کک آن ہو اور یہ بوٹ لاک ڈاؤن کا مالک ہو)
+	if isRaidLockdown(chatID) {
+		if s.AntiRaid.KickJoiners {
+			owner, _ := rdb.Get(ctx, raidActivePrefix+chatID).Result()
+			if strings.HasPrefix(owner, botID+":") {
+				kickRaidJoiners(client, botID, v.JID, v.Join)
+			}
+		}
+		return
+	}
+
+	key := botID + ":" + chatID
+	raidJoinsMu.Lock()
+	recent := raidJoins[key][:0]
+	for _, j := range raidJoins[key] {
+		if now.Sub(j.At) <= window {
+			recent = append(recent, j)
+		}
+	}
+	for _, jid := range v.Join {
+		recent = append(recent, raidJoin{JID: jid, At: now})
+	}
+	raidJoins[key] = recent
+	tripped := len(recent) >= threshold
+	var burst []types.JID
+	if tripped {
+		for _, j := range recent {
+			burst = append(burst, j.JID)
+		}
+		delete(raidJoins, key)
+	}
+	raidJoinsMu.Unlock()
+
+	if tripped {
+		triggerRaidLockdown(client, botID, s, v.JID, burst, cooldown)
+	}
+}
+
+// 🚨 لاک ڈاؤن
+func triggerRaidLockdown(client *whatsmeow.Client, botID string, s *GroupSettings, chat types.JID, burst []types.JID, cooldown time.Duration) {
+	chatID := chat.String()
+	if rdb == nil {
+		return
+	}
+	// ایک ہی بوٹ ایکشن لے، کی کامیاب ان لاک تک رہتی ہے (ری اسٹارٹ کے بعد بھی)
+	// ویلیو = بوٹ + وقت، تاکہ پرانا ان لاک بعد والے لاک ڈاؤن کو نہ کھولے
+	token := fmt.Sprintf("%s:%d", botID, time.Now().UnixNano())
+	if ok, err := rdb.SetNX(ctx, raidActivePrefix+chatID, token, 0).Result(); err != nil || !ok {
+		return
+	}
+	if !botIsGroupAdmin(client, chat) {
+		rdb.Del(ctx, raidActivePrefix+chatID)
+		return
+	}
+	// ⏳ ان لاک کا وقت ریڈیس میں، StartRaidScheduler اسے کھولے گا
+	rdb.ZAdd(ctx, raidUnlockKey, redis.Z{Score: float64(time.Now().Add(cooldown).Unix()), Member: chatID})
+	fmt.Printf("🚨 [ANTIRAID] %d joins burst in %s — locking down\n", len(burst), chatID)
+
+	bg := context.Background()
+	if err := client.SetGroupAnnounce(bg, chat, true); err != nil {
+		fmt.Printf("⚠️ [ANTIRAID] Announce lock failed: %v\n", err)
+	}
+	// لیک شدہ لنک منسوخ، نئی جنریشن ریکارڈ
+	linkStatus := "Reset"
+	if _, err := rotateInviteLinkWait(client, botID, chat, "antiraid", "bot", 40*time.Second); err != nil {
+		fmt.Printf("⚠️ [ANTIRAID] Link revoke failed: %v\n", err)
+		linkStatus = "⚠️ Not reset (.invite rotate)"
+	}
+	if s.AntiRaid.KickJoiners {
+		kickRaidJoiners(client, botID, chat, burst)
+	}
+	logModAction(ModLogEntry{
+		BotID:   botID,
+		GroupID: chatID,
+		Actor:   "bot",
+		Target:  "group",
+		Action:  "lockdown",
+		Rule:    "antiraid",
+		Reason:  fmt.Sprintf("%d joins burst", len(burst)),
+		Auto:    true,
+	})
+
+	kicked := "No"
+	if s.AntiRaid.KickJoiners {
+		kicked = "Yes"
+	}
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🚨 RAID DETECTED
+╠════════════════╣
+║ 👥 Joins: %d (burst)
+║ 🔒 Group: Locked
+║ 🔗 Invite Link: %s
+║ 👢 Joiners Removed: %s
+║ ⏳ Auto-unlock: %d min
+╠════════════════╣
+║ Admins: .raid off to unlock
+╚════════════════╝`, len(burst), linkStatus, kicked, int(cooldown.Minutes()))
+	sendRaidAlert(client, chat, msg)
+}
+
+// ⏰ بیک گراؤنڈ لوپ: وقت پورا ہونے والے لاک ڈاؤنز کھولیں (کوئی بھی ایڈمن بوٹ)
+func StartRaidScheduler(client *whatsmeow.Client) {
+	go func() {
+		for {
+			time.Sleep(raidUnlockTick)
+			if !isClientActive(client) {
+				return
+			}
+			if !client.IsConnected() || rdb == nil {
+				continue
+			}
+			due, err := rdb.ZRangeByScore(ctx, raidUnlockKey, &redis.ZRangeBy{
+				Min: "-inf",
+				Max: strconv.FormatInt(time.Now().Unix(), 10),
+			}).Result()
+			if err != nil {
+				continue
+			}
+			for _, chatID := range due {
+				token, err := rdb.Get(ctx, raidActivePrefix+chatID).Result()
+				if err != nil {
+					rdb.ZRem(ctx, raidUnlockKey, chatID) // .raid off سے پہلے ہی ختم
+					continue
+				}
+				chat, err := types.ParseJID(chatID)
+				if err != nil {
+					rdb.ZRem(ctx, raidUnlockKey, chatID)
+					continue
+				}
+				lockKey := raidUnlockLockPrefix + chatID
+				if ok, err := rdb.SetNX(ctx, lockKey, getCleanID(client.Store.ID.User), 2*time.Minute).Result(); err != nil || !ok {
+					continue
+				}
+				if botIsGroupAdmin(client, chat) {
+					liftRaidLockdown(client, chat, token, false)
+				}
+				rdb.Del(ctx, lockKey) // ناکام ہو تو اگلے ٹک پر کوئی بھی بوٹ دوبارہ کوشش کرے
+			}
+		}
+	}()
+}
+
+// 👢 جوائنرز کو بیچز میں نکالیں
+func kickRaidJoiners(client *whatsmeow.Client, botID string, chat types.JID, users []types.JID) {
+	for i := 0; i < len(users); i += raidKickBatch {
+		end := i + raidKickBatch
+		if end > len(users) {
+			end = len(users)
+		}
+		batch := users[i:end]
+		if _, err := client.UpdateGroupParticipants(context.Background(), chat, batch, whatsmeow.ParticipantChangeRemove); err != nil {
+			fmt.Printf("⚠️ [ANTIRAID] Kick failed: %v\n", err)
+			continue
+		}
+		for _, u := range batch {
+			logModAction(ModLogEntry{
+				BotID:   botID,
+				GroupID: chat.String(),
+				Actor:   "bot",
+				Target:  getCleanID(u.User),
+				Action:  "kick",
+				Rule:    "antiraid",
+				Reason:  "Joined during raid burst",
+				Auto:    true,
+			})
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// 📢 گروپ میں الرٹ، تمام ایڈمنز مینشن
+func sendRaidAlert(client *whatsmeow.Client, chat types.JID, msg string) {
+	var mentions []string
+	if info, err := client.GetGroupInfo(context.Background(), chat); err == nil {
+		for _, p := range info.Participants {
+			if participantIsAdmin(p) {
+				mentions = append(mentions, p.JID.String())
+				msg += " @" + p.JID.User
+			}
+		}
+	}
+	client.SendMessage(context.Background(), chat, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text:        proto.String(msg),
+			ContextInfo: &waProto.ContextInfo{MentionedJID: mentions},
+		},
+	})
+}
+
+// 🔓 لاک ڈاؤن ختم (manual = .raid off، ورنہ کول ڈاؤن)
+func liftRaidLockdown(client *whatsmeow.Client, chat types.JID, token string, manual bool) bool {
+	chatID := chat.String()
+	if rdb == nil {
+		return false
+	}
+	current, err := rdb.Get(ctx, raidActivePrefix+chatID).Result()
+	if !manual && (err != nil || current != token) {
+		return false // پہلے ہی .raid off ہو چکا یا نیا لاک ڈاؤن شروع ہو گیا
+	}
+
+	// نائٹ موڈ میں گروپ بند رہنا چاہیے
+	if sc := getNightMode(chatID); sc != nil && sc.Enabled && sc.State == "closed" {
+		clearRaidLockdown(chatID)
+		sendPlainText(client, chat, "✅ Raid lockdown lifted. Group stays closed for night mode.")
+		return true
+	}
+	if err := client.SetGroupAnnounce(context.Background(), chat, false); err != nil {
+		fmt.Printf("⚠️ [ANTIRAID] Unlock failed: %v\n", err)
+		return false // کی باقی، شیڈولر دوبارہ کوشش کرے گا
+	}
+	clearRaidLockdown(chatID)
+	sendPlainText(client, chat, "╔════════════════╗\n║ 🔓 LOCKDOWN LIFTED\n╠════════════════\n║ Group is open again\n║ Use .group link for\n║ the new invite link\n╚════════════════")
+	return true
+}
+
+func clearRaidLockdown(chatID string) {
+	rdb.Del(ctx, raidActivePrefix+chatID)
+	rdb.ZRem(ctx, raidUnlockKey, chatID)
+}
+
+// ==================== .raid ====================
+func handleRaid(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+	threshold, window, cooldown := raidLimits(s.AntiRaid)
+
+	if len(args) == 0 {
+		status := "🔴 OFF"
+		if s.AntiRaid.Enabled {
+			status = "🟢 ON"
+		}
+		lock := "No"
+		if isRaidLockdown(v.Info.Chat.String()) {
+			lock = "🔒 YES"
+		}
+		kick := "No"
+		if s.AntiRaid.KickJoiners {
+			kick = "Yes"
+		}
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🚨 ANTI-RAID
+╠════════════════╣
+║ Status: %s
+║ Trigger: %d joins / %ds
+║ Kick Joiners: %s
+║ Cooldown: %d min
+║ Lockdown Active: %s
+╠════════════════╣
+║ .raid on [joins] [seconds]
+║ .raid disable
+║ .raid off (lift lockdown)
+║ .raid kick on/off
+║ .raid cooldown <min>
+╚════════════════╝`, status, threshold, int(window.Seconds()), kick, int(cooldown.Minutes()), lock))
+		return
+	}
+
+	intArg := func(i int) int {
+		if len(args) > i {
+			if n, err := strconv.Atoi(args[i]); err == nil && n > 0 {
+				return n
+			}
+		}
+		return 0
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "on":
+		s.AntiRaid.Enabled = true
+		if n := intArg(1); n > 0 {
+			s.AntiRaid.Threshold = n
+		}
+		if n := intArg(2); n > 0 {
+			s.AntiRaid.WindowSec = n
+		}
+		saveGroupSettings(botID, s)
+		threshold, window, _ = raidLimits(s.AntiRaid)
+		replyMessage(client, v, fmt.Sprintf("✅ Anti-raid ON: lockdown at %d joins within %ds.", threshold, int(window.Seconds())))
+
+	case "disable":
+		s.AntiRaid.Enabled = false
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "✅ Anti-raid disabled.")
+
+	case "off", "unlock":
+		if !isRaidLockdown(v.Info.Chat.String()) {
+			replyMessage(client, v, "ℹ️ No active raid lockdown.")
+			return
+		}
+		if !liftRaidLockdown(client, v.Info.Chat, "", true) {
+			replyMessage(client, v, "⚠️ Failed to unlock (Give me Admin Rights)")
+		}
+
+	case "kick":
+		s.AntiRaid.KickJoiners = len(args) > 1 && strings.ToLower(args[1]) == "on"
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("✅ Kick burst joiners: %v", s.AntiRaid.KickJoiners))
+
+	case "cooldown":
+		n := intArg(1)
+		if n == 0 {
+			replyMessage(client, v, "⚠️ Usage: .raid cooldown <minutes>")
+			return
+		}
+		s.AntiRaid.CooldownMin = n
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("✅ Lockdown cooldown: %d min", n))
+
+	default:
+		replyMessage(client, v, "⚠️ Use: .raid on | disable | off | kick on/off | cooldown <min>")
+	}
+}
@@ -0,0 +1,447 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ==================== اپیل سسٹم ====================
+// نکالا گیا یا وارن شدہ یوزر بوٹ کو DM کرے: .appeal <group> <text>
+// کارڈ گروپ ایڈمنز (DM) یا اسٹاف گروپ میں جاتا ہے، ریپلائی approve/deny سے فیصلہ
+// منظوری پر: دوبارہ ایڈ (یا نیا انوائٹ لنک)، وارننگز صاف، ہر قدم ریکارڈ
+
+const (
+	appealPrefix     = "appeal:"
+	appealCardPrefix = "appeal:card:"
+	appealOpenPrefix = "appeal:open:" // appeal:open:<group>:<user> → ID (ایک وقت میں ایک)
+	appealMarkPrefix = "appeal:mark:" // appeal:mark:<group>:<user> → آخری ایکشن (مونگو کے بغیر بھی)
+	appealSeqPrefix  = "appeal:seq:"  // appeal:seq:<bot> → ہر بوٹ کا اپنا نمبر
+	appealTTL        = 7 * 24 * time.Hour
+	appealLookback   = 30 * 24 * time.Hour
+)
+
+// Appeal ایک اپیل کا ریکارڈ
+type Appeal struct {
+	ID        string    `json:"id"`
+	BotID     string    `json:"bot_id"`
+	GroupID   string    `json:"group_id"`
+	GroupName string    `json:"group_name"`
+	User      string    `json:"user"` // مکمل JID (DM اور ری ایڈ کے لیے)
+	UserClean string    `json:"user_clean"`
+	Record    string    `json:"record"` // آخری ایکشن (kick / warn ...)
+	Text      string    `json:"text"`
+	Status    string    `json:"status"` // pending / approved / denied
+	DecidedBy string    `json:"decided_by,omitempty"`
+	Steps     []string  `json:"steps"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (a *Appeal) step(format string, args ...interface{}) {
+	a.Steps = append(a.Steps, time.Now().Format("02 Jan 15:04")+" "+fmt.Sprintf(format, args...))
+}
+
+// ID صرف اپنے بوٹ میں یونیک، اس لیے کی میں بوٹ بھی
+func saveAppeal(a *Appeal) {
+	payload, _ := json.Marshal(a)
+	rdb.Set(ctx, appealPrefix+a.BotID+":"+a.ID, payload, appealTTL)
+}
+
+func getAppeal(botID, id string) *Appeal {
+	val, err := rdb.Get(ctx, appealPrefix+botID+":"+id).Result()
+	if err != nil {
+		return nil
+	}
+	var a Appeal
+	if json.Unmarshal([]byte(val), &a) != nil {
+		return nil
+	}
+	return &a
+}
+
+// 🔎 گروپ پہچانیں: پورا JID، .id والا نمبر، یا نام کا حصہ
+func resolveAppealGroup(client *whatsmeow.Client, arg string) (*types.GroupInfo, error) {
+	bg := context.Background()
+	if !strings.Contains(arg, "@") && strings.Trim(arg, "0123456789-") == "" {
+		arg += "@" + types.GroupServer
+	}
+	if strings.HasSuffix(arg, "@"+types.GroupServer) {
+		jid, err := types.ParseJID(arg)
+		if err != nil {
+			return nil, err
+		}
+		return client.GetGroupInfo(bg, jid)
+	}
+
+	groups, err := client.GetJoinedGroups(bg)
+	if err != nil {
+		return nil, err
+	}
+	var found []*types.GroupInfo
+	needle := strings.ToLower(arg)
+	for _, g := range groups {
+		if strings.Contains(strings.ToLower(g.Name), needle) {
+			found = append(found, g)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("no group matches %q", arg)
+	case 1:
+		return found[0], nil
+	}
+	return nil, fmt.Errorf("%d groups match %q, use the group ID", len(found), arg)
+}
+
+// 📌 کک / وارن / ڈیلیٹ کا ریڈیس نشان (logModAction سے، مونگو نہ ہو تب بھی اپیل ممکن)
+func markAppealable(e ModLogEntry) {
+	if rdb == nil || e.GroupID == "" || e.Target == "" {
+		return
+	}
+	switch e.Action {
+	case "kick", "warn", "delete":
+		record := fmt.Sprintf("%s (%s) %s", e.Action, e.Rule, e.Timestamp.Format("02 Jan"))
+		rdb.Set(ctx, appealMarkPrefix+e.GroupID+":"+e.Target, record, appealLookback)
+	}
+}
+
+// 📒 کیا اس یوزر پر اس گروپ میں کوئی ایکشن ہوا تھا؟ (مود لاگ، ورنہ ریڈیس نشان یا موجودہ وارننگز)
+func appealRecord(client *whatsmeow.Client, group string, user types.JID) string {
+	ids := userIDVariants(client, user)
+	entries, err := queryModLog(bson.M{
+		"group_id":  group,
+		"target":    bson.M{"$in": ids},
+		"action":    bson.M{"$in": []string{"kick", "warn", "delete"}},
+		"timestamp": bson.M{"$gte": time.Now().Add(-appealLookback)},
+	}, 1)
+	if err == nil && len(entries) > 0 {
+		e := entries[0]
+		return fmt.Sprintf("%s (%s) %s", e.Action, e.Rule, e.Timestamp.Format("02 Jan"))
+	}
+	for _, id := range ids {
+		if record, err := rdb.Get(ctx, appealMarkPrefix+group+":"+id).Result(); err == nil {
+			return record
+		}
+	}
+	s := getGroupSettings(getCleanID(client.Store.ID.User), group)
+	for key, n := range s.Warnings {
+		jid, err := types.ParseJID(key)
+		if err != nil || n == 0 {
+			continue
+		}
+		for _, id := range ids {
+			if getCleanID(jid.User) == id {
+				return fmt.Sprintf("warn %d/3", n)
+			}
+		}
+	}
+	return ""
+}
+
+// ==================== .appeal (DM) ====================
+func handleAppeal(client *whatsmeow.Client, v *events.Message, args []string) {
+	if v.Info.IsGroup {
+		replyMessage(client, v, "📩 Please send .appeal to me in private chat.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "⚠️ Redis not connected.")
+		return
+	}
+	if len(args) < 2 {
+		replyMessage(client, v, `╔════════════════╗
+║ 📝 APPEAL
+╠════════════════╣
+║ .appeal <group> <message>
+║ <group> = group ID or name
+║ Example:
+║ .appeal 1203630xxxx I'm sorry
+╚════════════════╝`)
+		return
+	}
+
+	info, err := resolveAppealGroup(client, args[0])
+	if err != nil {
+		replyMessage(client, v, "❌ "+err.Error())
+		return
+	}
+	user := v.Info.Sender.ToNonAD()
+	record := appealRecord(client, info.JID.String(), user)
+	if record == "" {
+		replyMessage(client, v, "ℹ️ No recent kick or warning found for you in that group.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	openKey := appealOpenPrefix + info.JID.String() + ":" + getCleanID(user.User)
+	seq, err := rdb.Incr(ctx, appealSeqPrefix+botID).Result()
+	if err != nil {
+		replyMessage(client, v, "❌ Could not create the appeal, try again.")
+		return
+	}
+	id := strconv.FormatInt(seq, 10)
+	if ok, _ := rdb.SetNX(ctx, openKey, id, appealTTL).Result(); !ok {
+		replyMessage(client, v, "⏳ You already have a pending appeal for this group.")
+		return
+	}
+
+	a := &Appeal{
+		ID:        id,
+		BotID:     botID,
+		GroupID:   info.JID.String(),
+		GroupName: info.Name,
+		User:      user.String(),
+		UserClean: getCleanID(user.User),
+		Record:    record,
+		Text:      strings.Join(args[1:], " "),
+		Status:    "pending",
+		CreatedAt: time.Now(),
+	}
+	a.step("submitted by %s", a.UserClean)
+
+	sent := sendAppealCard(client, a)
+	a.step("card sent to %d recipient(s)", sent)
+	saveAppeal(a)
+	logModAction(ModLogEntry{
+		BotID: botID, GroupID: a.GroupID, Actor: a.UserClean, Target: a.UserClean,
+		Action: "appeal", Rule: "appeal", Reason: "Appeal #" + a.ID, Excerpt: a.Text,
+	})
+
+	if sent == 0 {
+		replyMessage(client, v, "⚠️ Appeal saved, but no admin could be reached right now.")
+		return
+	}
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 📝 APPEAL SENT
+╠════════════════╣
+║ 🆔 #%s
+║ 👥 %s
+║ ⏳ Admins will review it
+╚════════════════╝`, a.ID, a.GroupName))
+}
+
+// 📤 کارڈ اسٹاف گروپ میں، ورنہ ہر ایڈمن کو DM
+func sendAppealCard(client *whatsmeow.Client, a *Appeal) int {
+	card := fmt.Sprintf(`╔════════════════╗
+║ 📝 APPEAL #%s
+╠════════════════╣
+║ 👥 Group: %s
+║ 👤 User: @%s
+║ 📒 Record: %s
+║ 💬 %s
+╠════════════════╣
+║ ↩️ Reply: approve
+║ ↩️ Reply: deny [reason]
+╚════════════════╝`, a.ID, a.GroupName, a.UserClean, a.Record, a.Text)
+
+	var targets []types.JID
+	s := getGroupSettings(a.BotID, a.GroupID)
+	if s.AppealStaff != "" {
+		if staff, err := types.ParseJID(s.AppealStaff); err == nil {
+			targets = append(targets, staff)
+		}
+	}
+	if len(targets) == 0 {
+		group, _ := types.ParseJID(a.GroupID)
+		for _, m := range groupMembers(client, group) {
+			if m.IsAdmin && (client.Store.ID == nil || m.JID.User != client.Store.ID.User) && m.JID.User != client.Store.LID.User {
+				targets = append(targets, m.JID)
+			}
+		}
+	}
+
+	sent := 0
+	for _, to := range targets {
+		resp, err := client.SendMessage(context.Background(), to, &waProto.Message{
+			ExtendedTextMessage: &waProto.ExtendedTextMessage{
+				Text:        proto.String(card),
+				ContextInfo: &waProto.ContextInfo{MentionedJID: []string{a.User}},
+			},
+		})
+		if err != nil {
+			continue
+		}
+		rdb.Set(ctx, appealCardPrefix+resp.ID, a.ID, appealTTL)
+		sent++
+	}
+	return sent
+}
+
+func isAppealCard(quotedID string) bool {
+	return rdb != nil && rdb.Exists(ctx, appealCardPrefix+quotedID).Val() > 0
+}
+
+// 📩 کارڈ پر approve / deny ریپلائی
+func handleAppealReply(client *whatsmeow.Client, v *events.Message, quotedID string) {
+	id, err := rdb.Get(ctx, appealCardPrefix+quotedID).Result()
+	if err != nil {
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	a := getAppeal(botID, id)
+	if a == nil {
+		return
+	}
+	group, _ := types.ParseJID(a.GroupID)
+	if !isAdmin(client, group, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only admins of that group can decide this appeal.")
+		return
+	}
+
+	words := strings.Fields(getText(v.Message))
+	if len(words) == 0 {
+		return
+	}
+	decision := strings.ToLower(words[0])
+	if decision != "approve" && decision != "deny" {
+		replyMessage(client, v, "⚠️ Reply with approve or deny [reason]")
+		return
+	}
+	// ایک ہی فیصلہ (کئی ایڈمنز ایک ساتھ ریپلائی کریں تو)
+	if ok, _ := rdb.SetNX(ctx, appealPrefix+"lock:"+botID+":"+a.ID, 1, appealTTL).Result(); !ok || a.Status != "pending" {
+		replyMessage(client, v, "ℹ️ Appeal #"+a.ID+" was already decided.")
+		return
+	}
+
+	admin := getCleanID(v.Info.Sender.User)
+	a.DecidedBy = admin
+	user, _ := types.ParseJID(a.User)
+	rdb.Del(ctx, appealOpenPrefix+a.GroupID+":"+a.UserClean)
+
+	if decision == "deny" {
+		reason := strings.TrimSpace(strings.Join(words[1:], " "))
+		if reason == "" {
+			reason = "No reason given"
+		}
+		a.Status = "denied"
+		a.step("denied by %s: %s", admin, reason)
+		saveAppeal(a)
+		logModAction(ModLogEntry{
+			BotID: botID, GroupID: a.GroupID, Actor: admin, Target: a.UserClean,
+			Action: "appeal_deny", Rule: "appeal", Reason: reason,
+		})
+		sendPlainText(client, user, fmt.Sprintf("❌ Your appeal #%s for *%s* was denied.\n📌 %s", a.ID, a.GroupName, reason))
+		replyMessage(client, v, "❌ Appeal #"+a.ID+" denied.")
+		return
+	}
+
+	a.Status = "approved"
+	a.step("approved by %s", admin)
+
+	// 1. وارننگز صاف
+	s := getGroupSettings(botID, a.GroupID)
+	ids := userIDVariants(client, user)
+	cleared := 0
+	for key := range s.Warnings {
+		for _, id := range ids {
+			if strings.HasPrefix(key, id+"@") || strings.HasPrefix(key, id+":") {
+				delete(s.Warnings, key)
+				cleared++
+				break
+			}
+		}
+	}
+	saveGroupSettings(botID, s)
+	for _, id := range ids {
+		rdb.Del(ctx, appealMarkPrefix+a.GroupID+":"+id)
+	}
+	a.step("cleared %d warning record(s)", cleared)
+
+	// 2. دوبارہ ایڈ، نہ ہو سکے تو انوائٹ لنک
+	result := ""
+	bg := context.Background()
+	if res, err := client.UpdateGroupParticipants(bg, group, []types.JID{user}, whatsmeow.ParticipantChangeAdd); err == nil && len(res) > 0 && res[0].Error == 0 {
+		a.step("re-added to group")
+		result = "✅ You have been added back."
+	} else if link, err := client.GetGroupInviteLink(bg, group, false); err == nil {
+		a.step("invite link sent")
+		result = "🔗 Join again: " + link
+	} else {
+		a.step("re-add and invite link failed")
+		result = "⚠️ Please ask an admin to add you back."
+	}
+	saveAppeal(a)
+	logModAction(ModLogEntry{
+		BotID: botID, GroupID: a.GroupID, Actor: admin, Target: a.UserClean,
+		Action: "appeal_approve", Rule: "appeal", Reason: strings.Join(a.Steps, " → "),
+	})
+
+	sendPlainText(client, user, fmt.Sprintf("✅ Your appeal #%s for *%s* was approved.\n%s", a.ID, a.GroupName, result))
+	replyMessage(client, v, fmt.Sprintf("✅ Appeal #%s approved.\n%s", a.ID, strings.Join(a.Steps, "\n")))
+}
+
+// 💡 کک کے بعد یوزر کو اپیل کا طریقہ
+func sendAppealHint(client *whatsmeow.Client, chat, user types.JID, reason string) {
+	sendPlainText(client, user.ToNonAD(), fmt.Sprintf(`╔════════════════╗
+║ 👢 REMOVED FROM GROUP
+╠════════════════╣
+║ 📌 %s
+║ 📝 Think this was a mistake?
+║ .appeal %s <message>
+╚════════════════╝`, reason, chat.User))
+}
+
+// ==================== .appeals (گروپ ایڈمن) ====================
+func handleAppealsAdmin(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	if len(args) > 0 && strings.ToLower(args[0]) == "staff" {
+		if len(args) < 2 {
+			replyMessage(client, v, "⚠️ Usage: .appeals staff <group-id> | off")
+			return
+		}
+		if strings.ToLower(args[1]) == "off" {
+			s.AppealStaff = ""
+			saveGroupSettings(botID, s)
+			replyMessage(client, v, "✅ Appeals will go to group admins by DM.")
+			return
+		}
+		target := args[1]
+		if !strings.Contains(target, "@") {
+			target += "@" + types.GroupServer
+		}
+		jid, err := types.ParseJID(target)
+		if err != nil || jid.Server != types.GroupServer {
+			replyMessage(client, v, "❌ Invalid group ID. Use .id inside the staff group.")
+			return
+		}
+		s.AppealStaff = jid.String()
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "✅ Appeals will be sent to staff group "+jid.User)
+		return
+	}
+
+	staff := "Admins (DM)"
+	if s.AppealStaff != "" {
+		staff = s.AppealStaff
+	}
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 📝 APPEALS
+╠════════════════╣
+║ 📬 Sent to: %s
+║ 🆔 This group: %s
+╠════════════════╣
+║ .appeals staff <group-id>
+║ .appeals staff off
+║ Users: DM .appeal <id> <msg>
+╚════════════════╝`, staff, v.Info.Chat.User))
+}
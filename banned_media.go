@@ -0,0 +1,371 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ==================== بینڈ میڈیا بلاک لسٹ ====================
+// ایڈمن میڈیا پر ریپلائی کر کے .banmedia کرے تو اس کا FileSHA256 اور (امیج کا) پرسیپچوئل ہیش محفوظ
+// بعد میں وہی یا ملتا جلتا (Hamming فاصلہ) میڈیا آئے تو آٹو ڈیلیٹ + گروپ کا ایکشن
+// سٹیکرز صرف ایگزیکٹ میچ (WebP میں عموماً تھمب نیل نہیں ہوتا، اس لیے ملتے جلتے سٹیکر نہیں پکڑے جاتے)
+
+const (
+	banMediaPrefix      = "banmedia:" // banmedia:<chat> یا banmedia:global
+	banMediaGlobal      = "global"
+	banMediaDefaultDist = 6
+)
+
+// BannedMedia ایک بلاک شدہ میڈیا
+type BannedMedia struct {
+	ID       string    `json:"id"`
+	SHA256   string    `json:"sha256"`
+	PHash    uint64    `json:"phash"`
+	HasPHash bool      `json:"has_phash"`
+	Type     string    `json:"type"`
+	Note     string    `json:"note"`
+	By       string    `json:"by"`
+	At       time.Time `json:"at"`
+}
+
+// 🖼️ تھمب نیل سے dHash (64 بٹ)، تھمب نیل نہ ہو یا ڈیکوڈ نہ ہو تو false
+func perceptualHash(thumb []byte) (uint64, bool) {
+	if len(thumb) == 0 {
+		return 0, false
+	}
+	img, _, err := image.Decode(bytes.NewReader(thumb))
+	if err != nil {
+		return 0, false
+	}
+	b := img.Bounds()
+	if b.Dx() < 9 || b.Dy() < 8 {
+		return 0, false
+	}
+	// 9x8 گرے سکیل گرڈ (ہر خانے کا اوسط)
+	var grid [8][9]float64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 9; x++ {
+			x0, x1 := b.Min.X+x*b.Dx()/9, b.Min.X+(x+1)*b.Dx()/9
+			y0, y1 := b.Min.Y+y*b.Dy()/8, b.Min.Y+(y+1)*b.Dy()/8
+			var sum float64
+			n := 0
+			for py := y0; py < y1; py++ {
+				for px := x0; px < x1; px++ {
+					r, g, bl, _ := img.At(px, py).RGBA()
+					sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(bl)
+					n++
+				}
+			}
+			if n > 0 {
+				grid[y][x] = sum / float64(n)
+			}
+		}
+	}
+	var h uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			h <<= 1
+			if grid[y][x] < grid[y][x+1] {
+				h |= 1
+			}
+		}
+	}
+	return h, true
+}
+
+// 📎 میسج سے بلاک لسٹ کے لیے فنگر پرنٹ
+func bannedMediaFingerprint(msg *waProto.Message) (*BannedMedia, bool) {
+	sum := mediaFileSHA256(msg)
+	if len(sum) == 0 {
+		return nil, false
+	}
+	e := &BannedMedia{SHA256: hex.EncodeToString(sum)}
+	e.ID = e.SHA256[:8]
+	switch {
+	case msg.GetImageMessage() != nil:
+		e.Type = "image"
+		e.PHash, e.HasPHash = perceptualHash(msg.GetImageMessage().GetJPEGThumbnail())
+	case msg.GetStickerMessage() != nil:
+		e.Type = "sticker" // صرف SHA256
+	case msg.GetVideoMessage() != nil:
+		e.Type = "video"
+	case msg.GetDocumentMessage() != nil:
+		e.Type = "document"
+	case msg.GetAudioMessage() != nil:
+		e.Type = "audio"
+	}
+	return e, true
+}
+
+func listBannedMedia(scope string) []BannedMedia {
+	if rdb == nil {
+		return nil
+	}
+	all, err := rdb.HGetAll(ctx, banMediaPrefix+scope).Result()
+	if err != nil {
+		return nil
+	}
+	out := make([]BannedMedia, 0, len(all))
+	for _, val := range all {
+		var e BannedMedia
+		if json.Unmarshal([]byte(val), &e) == nil {
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].At.After(out[j].At) })
+	return out
+}
+
+// 🔍 میچ: پہلے بالکل وہی فائل، پھر Hamming فاصلہ
+func matchBannedMedia(scopes []string, fp *BannedMedia, maxDist int) (*BannedMedia, int) {
+	for _, scope := range scopes {
+		for _, e := range listBannedMedia(scope) {
+			if e.SHA256 == fp.SHA256 {
+				return &e, 0
+			}
+			if e.HasPHash && fp.HasPHash {
+				if d := bits.OnesCount64(e.PHash ^ fp.PHash); d <= maxDist {
+					return &e, d
+				}
+			}
+		}
+	}
+	return nil, -1
+}
+
+func bannedMediaLimits(s *GroupSettings) (action string, dist int) {
+	action, dist = s.BannedMedia.Action, s.BannedMedia.MaxDistance
+	if action == "" {
+		action = "delete"
+	}
+	if dist == 0 {
+		dist = banMediaDefaultDist
+	} else if dist < 0 {
+		dist = 0 // صرف بالکل ایک جیسی فائل
+	}
+	return
+}
+
+// 🛡️ گارڈ: true = بینڈ میڈیا ملا، ایکشن شروع
+func enforceBannedMedia(client *whatsmeow.Client, v *events.Message) bool {
+	if rdb == nil || len(mediaFileSHA256(v.Message)) == 0 {
+		return false
+	}
+	fp, ok := bannedMediaFingerprint(v.Message)
+	if !ok {
+		return false
+	}
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+	action, dist := bannedMediaLimits(s)
+
+	hit, d := matchBannedMedia([]string{v.Info.Chat.String(), banMediaGlobal}, fp, dist)
+	if hit == nil {
+		return false
+	}
+	reason := "Banned media #" + hit.ID
+	if d > 0 {
+		reason += fmt.Sprintf(" (similar, distance %d)", d)
+	}
+	go takeSecurityAction(client, v, s, "banmedia", action, reason, botID)
+	return true
+}
+
+// ==================== .banmedia ====================
+func handleBanMedia(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "⚠️ Redis not connected.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	chatID := v.Info.Chat.String()
+	s := getGroupSettings(botID, chatID)
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	switch sub {
+	case "list":
+		action, dist := bannedMediaLimits(s)
+		out := fmt.Sprintf("╔════════════════╗\n║ 🚫 BANNED MEDIA\n╠════════════════\n║ Action: %s | Distance: %d\n", securityActionText(action), dist)
+		total := 0
+		for _, scope := range []string{chatID, banMediaGlobal} {
+			label := "📍 This Group"
+			if scope == banMediaGlobal {
+				label = "🌐 Global"
+			}
+			entries := listBannedMedia(scope)
+			out += fmt.Sprintf("╠════════════════\n║ %s (%d)\n", label, len(entries))
+			for i, e := range entries {
+				if i >= 20 {
+					out += fmt.Sprintf("║ ... and %d more\n", len(entries)-i)
+					break
+				}
+				note := ""
+				if e.Note != "" {
+					note = " | " + e.Note
+				}
+				out += fmt.Sprintf("║ #%s %s%s\n", e.ID, e.Type, note)
+			}
+			total += len(entries)
+		}
+		if total == 0 {
+			replyMessage(client, v, "📭 No banned media yet. Reply to a sticker/image with .banmedia")
+			return
+		}
+		out += "╚════════════════"
+		replyMessage(client, v, out)
+		return
+
+	case "del", "remove":
+		if len(args) < 2 {
+			replyMessage(client, v, "⚠️ Usage: .banmedia del <id>")
+			return
+		}
+		id := strings.TrimPrefix(strings.ToLower(args[1]), "#")
+		if n, _ := rdb.HDel(ctx, banMediaPrefix+chatID, id).Result(); n > 0 {
+			replyMessage(client, v, "✅ Removed #"+id)
+			return
+		}
+		if isOwner(client, v.Info.Sender) {
+			if n, _ := rdb.HDel(ctx, banMediaPrefix+banMediaGlobal, id).Result(); n > 0 {
+				replyMessage(client, v, "✅ Removed global #"+id)
+				return
+			}
+		}
+		replyMessage(client, v, "❌ No banned media with id #"+id)
+		return
+
+	case "action":
+		if len(args) < 2 {
+			replyMessage(client, v, "⚠️ Usage: .banmedia action delete/deletewarn/deletekick")
+			return
+		}
+		a := strings.ToLower(args[1])
+		if a != "delete" && a != "deletewarn" && a != "deletekick" {
+			replyMessage(client, v, "⚠️ Usage: .banmedia action delete/deletewarn/deletekick")
+			return
+		}
+		s.BannedMedia.Action = a
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "✅ Banned media action: "+securityActionText(a))
+		return
+
+	case "distance":
+		if len(args) < 2 {
+			replyMessage(client, v, "⚠️ Usage: .banmedia distance <0-20> (0 = exact only)")
+			return
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 0 || n > 20 {
+			replyMessage(client, v, "⚠️ Usage: .banmedia distance <0-20> (0 = exact only)")
+			return
+		}
+		s.BannedMedia.MaxDistance = n
+		if n == 0 {
+			s.BannedMedia.MaxDistance = -1 // 0 = ڈیفالٹ، اس لیے صرف ایگزیکٹ میچ کو -1 محفوظ کریں
+		}
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("✅ Similarity distance set to %d", n))
+		return
+	}
+
+	// 📌 ریپلائی والے میڈیا کو بین کریں (.banmedia [global] [note])
+	quoted := v.Message.GetExtendedTextMessage().GetContextInfo().GetQuotedMessage()
+	if quoted == nil {
+		replyMessage(client, v, `╔════════════════╗
+║ 🚫 BANNED MEDIA
+╠════════════════╣
+║ Reply to media:
+║ .banmedia [note]
+║ .banmedia global [note]
+║ .banmedia list
+║ .banmedia del <id>
+║ .banmedia action <type>
+║ .banmedia distance <n>
+╚════════════════╝`)
+		return
+	}
+	fp, ok := bannedMediaFingerprint(quoted)
+	if !ok {
+		replyMessage(client, v, "❌ Reply to an image, sticker, video, audio or document.")
+		return
+	}
+
+	scope := chatID
+	if sub == "global" {
+		if !isOwner(client, v.Info.Sender) {
+			replyMessage(client, v, "❌ Only Owner can ban media globally.")
+			return
+		}
+		scope = banMediaGlobal
+		args = args[1:]
+	}
+	fp.Note = strings.Join(args, " ")
+	fp.By = getCleanID(v.Info.Sender.User)
+	fp.At = time.Now()
+
+	payload, _ := json.Marshal(fp)
+	if err := rdb.HSet(ctx, banMediaPrefix+scope, fp.ID, payload).Err(); err != nil {
+		replyMessage(client, v, "❌ Failed: "+err.Error())
+		return
+	}
+
+	// اصل میسج بھی ڈیلیٹ کریں
+	ci := v.Message.GetExtendedTextMessage().GetContextInfo()
+	if qp, err := types.ParseJID(ci.GetParticipant()); err == nil {
+		client.SendMessage(context.Background(), v.Info.Chat, client.BuildRevoke(v.Info.Chat, qp, ci.GetStanzaID()))
+	}
+	logModAction(ModLogEntry{
+		BotID:     botID,
+		GroupID:   chatID,
+		Actor:     fp.By,
+		Action:    "banmedia",
+		Rule:      "manual",
+		Reason:    "Banned " + fp.Type + " #" + fp.ID,
+		MessageID: ci.GetStanzaID(),
+	})
+
+	similar := "exact match only"
+	if fp.HasPHash {
+		similar = "exact + similar copies"
+	}
+	where := "this group"
+	if scope == banMediaGlobal {
+		where = "all groups"
+	}
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🚫 MEDIA BANNED
+╠════════════════╣
+║ 🆔 #%s (%s)
+║ 📍 Scope: %s
+║ 🔍 Blocks: %s
+╚════════════════╝`, fp.ID, fp.Type, where, similar))
+}
@@ -0,0 +1,527 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ==================== اونر براڈکاسٹ ====================
+// ایک میسج (ٹیکسٹ یا ریپلائی کیا ہوا میڈیا) تمام یا ٹیگ والے گروپس میں، وقفے + جِٹر کے ساتھ
+// جاب کی حالت ریڈیس میں، بوٹ ری سٹارٹ ہو تو وہیں سے دوبارہ شروع
+
+const (
+	bcastJobPrefix    = "bcast:job:"    // bcast:job:<bot>
+	bcastLockPrefix   = "bcast:lock:"   // ایک وقت میں ایک رنر
+	bcastTagPrefix    = "bcast:tag:"    // bcast:tag:<bot>:<tag> → گروپ JIDs کا سیٹ
+	bcastConfigPrefix = "bcast:config:" // وقفہ / جِٹر
+	bcastLockTTL      = 2 * time.Minute // چھوٹا TTL، رنر ہارٹ بیٹ سے بڑھاتا رہے (کریش ہو تو جلد ختم)
+	bcastHeartbeat    = 30 * time.Second
+	bcastResumeTries  = 10
+	bcastReportEvery  = 10
+	bcastMaxDelaySec  = 600
+)
+
+// BroadcastConfig بھیجنے کی رفتار
+type BroadcastConfig struct {
+	DelaySec  int `json:"delay_sec"`
+	JitterSec int `json:"jitter_sec"`
+}
+
+// BroadcastJob ایک جاری / مکمل براڈکاسٹ
+type BroadcastJob struct {
+	ID        string            `json:"id"`
+	Scope     string            `json:"scope"` // all یا ٹیگ
+	Targets   []string          `json:"targets"`
+	Names     map[string]string `json:"names"`
+	Next      int               `json:"next"`
+	Sent      int               `json:"sent"`
+	Failed    []string          `json:"failed"`
+	Text      string            `json:"text"`
+	Message   []byte            `json:"message,omitempty"` // میڈیا میسج کا پروٹو
+	Status    string            `json:"status"`            // running / paused / done / cancelled
+	ReportTo  string            `json:"report_to"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+func getBroadcastConfig(botID string) *BroadcastConfig {
+	cfg := &BroadcastConfig{DelaySec: 8, JitterSec: 5}
+	if rdb != nil {
+		if val, err := rdb.Get(ctx, bcastConfigPrefix+botID).Result(); err == nil {
+			json.Unmarshal([]byte(val), cfg)
+		}
+	}
+	return cfg
+}
+
+func getBroadcastJob(botID string) *BroadcastJob {
+	if rdb == nil {
+		return nil
+	}
+	val, err := rdb.Get(ctx, bcastJobPrefix+botID).Result()
+	if err != nil {
+		return nil
+	}
+	var job BroadcastJob
+	if json.Unmarshal([]byte(val), &job) != nil {
+		return nil
+	}
+	return &job
+}
+
+func saveBroadcastJob(botID string, job *BroadcastJob) {
+	job.UpdatedAt = time.Now()
+	if payload, err := json.Marshal(job); err == nil {
+		rdb.Set(ctx, bcastJobPrefix+botID, payload, 30*24*time.Hour)
+	}
+}
+
+// 🎯 ٹارگٹ گروپس: all = تمام جوائنڈ، ورنہ ٹیگ والے (جن میں بوٹ ابھی بھی ہے)
+func broadcastTargets(client *whatsmeow.Client, botID, scope string) ([]*types.GroupInfo, error) {
+	groups, err := client.GetJoinedGroups(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	if scope != "all" {
+		tagged, _ := rdb.SMembers(ctx, bcastTagPrefix+botID+":"+scope).Result()
+		if len(tagged) == 0 {
+			return nil, fmt.Errorf("no groups tagged %q", scope)
+		}
+		want := make(map[string]bool, len(tagged))
+		for _, t := range tagged {
+			want[t] = true
+		}
+		var out []*types.GroupInfo
+		for _, g := range groups {
+			if want[g.JID.String()] {
+				out = append(out, g)
+			}
+		}
+		groups = out
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups, nil
+}
+
+// 🔤 {group} {count} {desc} {date}
+func renderBroadcastText(text string, info *types.GroupInfo) string {
+	if text == "" {
+		return ""
+	}
+	r := strings.NewReplacer(
+		"{group}", info.Name,
+		"{count}", strconv.Itoa(len(info.Participants)),
+		"{desc}", info.Topic,
+		"{date}", time.Now().Format("02 Jan 2006"),
+	)
+	return r.Replace(text)
+}
+
+// ایک گروپ کے لیے میسج (میڈیا ہو تو کیپشن پر ٹیمپلیٹ)
+func buildBroadcastMessage(job *BroadcastJob, info *types.GroupInfo) *waProto.Message {
+	text := renderBroadcastText(job.Text, info)
+	if len(job.Message) == 0 {
+		return &waProto.Message{Conversation: proto.String(text)}
+	}
+	var msg waProto.Message
+	if proto.Unmarshal(job.Message, &msg) != nil {
+		return &waProto.Message{Conversation: proto.String(text)}
+	}
+	if text != "" {
+		switch {
+		case msg.ImageMessage != nil:
+			msg.ImageMessage.Caption = proto.String(text)
+		case msg.VideoMessage != nil:
+			msg.VideoMessage.Caption = proto.String(text)
+		case msg.DocumentMessage != nil:
+			msg.DocumentMessage.Caption = proto.String(text)
+		}
+	}
+	return &msg
+}
+
+func broadcastProgress(job *BroadcastJob) string {
+	return fmt.Sprintf("📣 Broadcast %s: %d/%d (✅ %d ❌ %d)", job.Status, job.Next, len(job.Targets), job.Sent, len(job.Failed))
+}
+
+// 🔐 لاک صرف اپنے ٹوکن پر تازہ / ڈیلیٹ ہو (پرانا رنر نئے رنر کا لاک نہ چھیڑے)
+var (
+	bcastLockRefresh = redis.NewScript(`if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("PEXPIRE", KEYS[1], ARGV[2]) end return 0`)
+	bcastLockRelease = redis.NewScript(`if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) end return 0`)
+)
+
+// false = لاک ہاتھ سے نکل گیا (ایکسپائر ہو کر کسی اور رنر کے پاس)
+func refreshBroadcastLock(lockKey, token string) bool {
+	n, err := bcastLockRefresh.Run(ctx, rdb, []string{lockKey}, token, bcastLockTTL.Milliseconds()).Int()
+	return err == nil && n == 1
+}
+
+// 💓 وقفے کے دوران لاک تازہ کرتے رہیں
+func broadcastWait(lockKey, token string, delay time.Duration) bool {
+	for delay > 0 {
+		step := min(delay, bcastHeartbeat)
+		if !refreshBroadcastLock(lockKey, token) {
+			return false
+		}
+		time.Sleep(step)
+		delay -= step
+	}
+	return refreshBroadcastLock(lockKey, token)
+}
+
+// 🚀 رنر: لاک لے کر جاب کی حالت سے آگے بڑھیں (false = لاک کسی اور کے پاس)
+func runBroadcast(client *whatsmeow.Client) bool {
+	botID := getCleanID(client.Store.ID.User)
+	lockKey := bcastLockPrefix + botID
+	token := fmt.Sprintf("%d:%d", time.Now().UnixNano(), rand.Int63())
+	if ok, _ := rdb.SetNX(ctx, lockKey, token, bcastLockTTL).Result(); !ok {
+		return false
+	}
+	defer bcastLockRelease.Run(ctx, rdb, []string{lockKey}, token)
+
+	cfg := getBroadcastConfig(botID)
+	for {
+		if !isClientActive(client) {
+			return true // جاب "running" رہتی ہے، نیا کلائنٹ وہیں سے شروع کرے گا
+		}
+		job := getBroadcastJob(botID)
+		if job == nil || job.Status != "running" {
+			return true
+		}
+		reportTo, _ := types.ParseJID(job.ReportTo)
+
+		if job.Next >= len(job.Targets) {
+			job.Status = "done"
+			saveBroadcastJob(botID, job)
+			out := fmt.Sprintf("╔════════════════╗\n║ 📣 BROADCAST DONE\n╠════════════════╣\n║ 🎯 Groups: %d\n║ ✅ Sent: %d\n║ ❌ Failed: %d\n", len(job.Targets), job.Sent, len(job.Failed))
+			for i, f := range job.Failed {
+				if i == 10 {
+					out += fmt.Sprintf("║ ... and %d more\n", len(job.Failed)-i)
+					break
+				}
+				out += "║  • " + orDash(job.Names[f]) + "\n"
+			}
+			out += "╚════════════════╝"
+			if !reportTo.IsEmpty() {
+				sendPlainText(client, reportTo, out)
+			}
+			return true
+		}
+
+		// بھیجنے سے پہلے یقینی بنائیں کہ لاک ابھی بھی اسی رنر کا ہے (ورنہ ڈبل میسج)
+		if !refreshBroadcastLock(lockKey, token) {
+			fmt.Printf("⚠️ [BROADCAST] Lock lost for %s, stopping this runner\n", botID)
+			return true
+		}
+		target := job.Targets[job.Next]
+		ok := false
+		if jid, err := types.ParseJID(target); err == nil {
+			if info, err := client.GetGroupInfo(context.Background(), jid); err == nil {
+				_, err = client.SendMessage(context.Background(), jid, buildBroadcastMessage(job, info))
+				ok = err == nil
+			}
+		}
+		if ok {
+			job.Sent++
+		} else {
+			job.Failed = append(job.Failed, target)
+		}
+		job.Next++
+
+		// درمیان میں pause/cancel ہوا ہو تو وہ حالت برقرار رکھیں
+		if latest := getBroadcastJob(botID); latest != nil && latest.ID == job.ID && latest.Status != "running" {
+			job.Status = latest.Status
+		}
+		saveBroadcastJob(botID, job)
+
+		if job.Next%bcastReportEvery == 0 && job.Next < len(job.Targets) && !reportTo.IsEmpty() {
+			sendPlainText(client, reportTo, broadcastProgress(job))
+		}
+		if job.Next < len(job.Targets) {
+			delay := time.Duration(cfg.DelaySec) * time.Second
+			if cfg.JitterSec > 0 {
+				delay += time.Duration(rand.Intn(cfg.JitterSec*1000)) * time.Millisecond
+			}
+			if !broadcastWait(lockKey, token, delay) {
+				fmt.Printf("⚠️ [BROADCAST] Lock lost for %s, stopping this runner\n", botID)
+				return true
+			}
+		}
+	}
+}
+
+// 🔁 کنیکٹ ہونے پر ادھوری جاب دوبارہ شروع (main.go سے)
+// پچھلے پروسیس کا لاک زیادہ سے زیادہ bcastLockTTL میں ایکسپائر ہوتا ہے، تب تک کوشش جاری
+func StartBroadcastResumer(client *whatsmeow.Client) {
+	go func() {
+		time.Sleep(30 * time.Second) // کنکشن سیٹ ہونے دیں
+		for attempt := 0; attempt < bcastResumeTries; attempt++ {
+			if attempt > 0 {
+				time.Sleep(bcastLockTTL / 2)
+			}
+			if !isClientActive(client) {
+				return
+			}
+			if rdb == nil || !client.IsConnected() {
+				continue
+			}
+			job := getBroadcastJob(getCleanID(client.Store.ID.User))
+			if job == nil || job.Status != "running" {
+				return
+			}
+			fmt.Printf("📣 [BROADCAST] Resuming job %s at %d/%d\n", job.ID, job.Next, len(job.Targets))
+			if runBroadcast(client) {
+				return
+			}
+		}
+		fmt.Printf("⚠️ [BROADCAST] Gave up resuming for %s: lock still held, use .bc resume\n", getCleanID(client.Store.ID.User))
+	}()
+}
+
+// ==================== .bc ====================
+func handleBroadcast(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Owner!")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "⚠️ Redis not connected.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	switch sub {
+	case "send", "dry":
+		if len(args) < 2 {
+			replyMessage(client, v, "⚠️ Usage: .bc "+sub+" all|<tag> <text> (or reply to media)")
+			return
+		}
+		startBroadcast(client, v, botID, strings.ToLower(args[1]), sub == "dry")
+
+	case "status":
+		job := getBroadcastJob(botID)
+		if job == nil {
+			replyMessage(client, v, "📣 No broadcast yet.")
+			return
+		}
+		replyMessage(client, v, broadcastProgress(job))
+
+	case "pause", "cancel":
+		job := getBroadcastJob(botID)
+		if job == nil || job.Status != "running" {
+			replyMessage(client, v, "⚠️ No broadcast is running.")
+			return
+		}
+		job.Status = map[string]string{"pause": "paused", "cancel": "cancelled"}[sub]
+		saveBroadcastJob(botID, job)
+		replyMessage(client, v, broadcastProgress(job))
+
+	case "resume":
+		job := getBroadcastJob(botID)
+		if job == nil || job.Status != "paused" {
+			replyMessage(client, v, "⚠️ No paused broadcast.")
+			return
+		}
+		job.Status, job.ReportTo = "running", v.Info.Chat.String()
+		saveBroadcastJob(botID, job)
+		replyMessage(client, v, broadcastProgress(job))
+		go runBroadcast(client)
+
+	case "delay":
+		cfg := getBroadcastConfig(botID)
+		if len(args) > 1 {
+			d, err := strconv.Atoi(args[1])
+			if err != nil || d < 2 || d > bcastMaxDelaySec {
+				replyMessage(client, v, fmt.Sprintf("⚠️ Delay must be 2-%d seconds.", bcastMaxDelaySec))
+				return
+			}
+			cfg.DelaySec = d
+		}
+		if len(args) > 2 {
+			j, err := strconv.Atoi(args[2])
+			if err != nil || j < 0 || j > bcastMaxDelaySec {
+				replyMessage(client, v, fmt.Sprintf("⚠️ Jitter must be 0-%d seconds.", bcastMaxDelaySec))
+				return
+			}
+			cfg.JitterSec = j
+		}
+		payload, _ := json.Marshal(cfg)
+		rdb.Set(ctx, bcastConfigPrefix+botID, payload, 0)
+		replyMessage(client, v, fmt.Sprintf("⏱️ Broadcast delay: %ds + up to %ds jitter", cfg.DelaySec, cfg.JitterSec))
+
+	case "tag":
+		handleBroadcastTag(client, v, botID, args[1:])
+
+	case "tags":
+		keys, _ := rdb.Keys(ctx, bcastTagPrefix+botID+":*").Result()
+		if len(keys) == 0 {
+			replyMessage(client, v, "🏷️ No tags yet. In a group: .bc tag <name> add")
+			return
+		}
+		sort.Strings(keys)
+		out := "╔════════════════╗\n║ 🏷️ BROADCAST TAGS\n╠════════════════╣\n"
+		for _, k := range keys {
+			out += fmt.Sprintf("║ %s — %d groups\n", strings.TrimPrefix(k, bcastTagPrefix+botID+":"), rdb.SCard(ctx, k).Val())
+		}
+		replyMessage(client, v, out+"╚════════════════╝")
+
+	default:
+		cfg := getBroadcastConfig(botID)
+		status := "none"
+		if job := getBroadcastJob(botID); job != nil {
+			status = broadcastProgress(job)
+		}
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 📣 BROADCAST
+╠════════════════╣
+║ Last: %s
+║ Delay: %ds + %ds jitter
+╠════════════════╣
+║ .bc dry all|<tag> <text>
+║ .bc send all|<tag> <text>
+║   (reply to media to send it)
+║ .bc status / pause / resume / cancel
+║ .bc delay <sec> [jitter]
+║ .bc tag <tag> add|del [group]
+║ .bc tags
+╠════════════════╣
+║ {group} {count} {desc} {date}
+╚════════════════╝`, status, cfg.DelaySec, cfg.JitterSec))
+	}
+}
+
+// 📝 جاب بنائیں (dry = صرف لسٹ اور پری ویو)
+func startBroadcast(client *whatsmeow.Client, v *events.Message, botID, scope string, dry bool) {
+	if job := getBroadcastJob(botID); !dry && job != nil && (job.Status == "running" || job.Status == "paused") {
+		replyMessage(client, v, "⏳ Another broadcast is "+job.Status+". Use .bc cancel first.")
+		return
+	}
+	groups, err := broadcastTargets(client, botID, scope)
+	if err != nil {
+		replyMessage(client, v, "❌ "+err.Error())
+		return
+	}
+	if len(groups) == 0 {
+		replyMessage(client, v, "⚠️ No target groups.")
+		return
+	}
+
+	text := greetingArgText(v, 3)
+	quoted := v.Message.GetExtendedTextMessage().GetContextInfo().GetQuotedMessage()
+	media, downloadable, mediaType, kind := noteMediaPart(quoted)
+	if media == nil && text == "" && quoted != nil {
+		text = getText(quoted)
+	}
+	if media == nil && text == "" {
+		replyMessage(client, v, "⚠️ Give the broadcast text, or reply to a message or media.")
+		return
+	}
+
+	cfg := getBroadcastConfig(botID)
+	eta := time.Duration(len(groups)-1) * time.Duration(cfg.DelaySec*1000+cfg.JitterSec*500) * time.Millisecond
+
+	if dry {
+		out := fmt.Sprintf("╔════════════════╗\n║ 🧪 BROADCAST DRY RUN\n╠════════════════╣\n║ 🎯 Scope: %s\n║ 👥 Groups: %d\n║ ⏱️ ETA: ~%s\n", scope, len(groups), eta.Round(time.Second))
+		if kind != "" {
+			out += "║ 📎 Media: " + kind + "\n"
+		}
+		out += "╠════════════════╣\n"
+		for i, g := range groups {
+			if i == 50 {
+				out += fmt.Sprintf("║ ... and %d more\n", len(groups)-i)
+				break
+			}
+			out += fmt.Sprintf("║ %d. %s (%d)\n", i+1, g.Name, len(g.Participants))
+		}
+		if preview := renderBroadcastText(text, groups[0]); preview != "" {
+			out += "╠════════════════╣\n║ 👁️ Preview (" + groups[0].Name + "):\n" + preview + "\n"
+		}
+		replyMessage(client, v, out+"╚════════════════╝")
+		return
+	}
+
+	job := &BroadcastJob{
+		ID:        v.Info.ID,
+		Scope:     scope,
+		Names:     make(map[string]string, len(groups)),
+		Text:      text,
+		Status:    "running",
+		ReportTo:  v.Info.Chat.String(),
+		CreatedAt: time.Now(),
+	}
+	for _, g := range groups {
+		job.Targets = append(job.Targets, g.JID.String())
+		job.Names[g.JID.String()] = g.Name
+	}
+	if media != nil {
+		// تازہ اپلوڈ تاکہ لمبی جاب میں ریفرنس ایکسپائر نہ ہو
+		if data, err := client.Download(context.Background(), downloadable); err == nil {
+			if up, err := client.Upload(context.Background(), data, mediaType); err == nil {
+				applyNoteUpload(media, up)
+			}
+		}
+		job.Message, _ = proto.Marshal(media)
+	}
+	saveBroadcastJob(botID, job)
+
+	replyMessage(client, v, fmt.Sprintf("📣 Broadcasting to %d groups (%s), ETA ~%s.\n.bc status / pause / cancel", len(groups), scope, eta.Round(time.Second)))
+	go runBroadcast(client)
+}
+
+// 🏷️ .bc tag <tag> add|del [group]
+func handleBroadcastTag(client *whatsmeow.Client, v *events.Message, botID string, args []string) {
+	if len(args) < 2 {
+		replyMessage(client, v, "⚠️ Usage: .bc tag <tag> add|del [group-jid | name]")
+		return
+	}
+	tag, action := strings.ToLower(args[0]), strings.ToLower(args[1])
+	if tag == "all" {
+		replyMessage(client, v, "⚠️ \"all\" is reserved.")
+		return
+	}
+
+	group, name := v.Info.Chat, ""
+	if len(args) > 2 {
+		info, err := resolveAppealGroup(client, strings.Join(args[2:], " "))
+		if err != nil {
+			replyMessage(client, v, "❌ "+err.Error())
+			return
+		}
+		group, name = info.JID, info.Name
+	} else if !v.Info.IsGroup {
+		replyMessage(client, v, "⚠️ Run this in the group, or give the group ID/name.")
+		return
+	}
+	if name == "" {
+		name = group.User
+	}
+
+	key := bcastTagPrefix + botID + ":" + tag
+	switch action {
+	case "add":
+		rdb.SAdd(ctx, key, group.String())
+		replyMessage(client, v, fmt.Sprintf("🏷️ %s tagged \"%s\" (%d groups)", name, tag, rdb.SCard(ctx, key).Val()))
+	case "del", "remove":
+		rdb.SRem(ctx, key, group.String())
+		replyMessage(client, v, fmt.Sprintf("🏷️ %s removed from \"%s\"", name, tag))
+	default:
+		replyMessage(client, v, "⚠️ Usage: .bc tag <tag> add|del [group]")
+	}
+}
@@ -143,6 +143,12 @@ func handler(botClient *whatsmeow.Client, evt interface{}) {
 			}
 		}()
 
+	case *events.GroupInfo:
+		handleGroupEvents(botClient, v)
+
+	case *events.Picture:
+		handleGroupEvents(botClient, v)
+
 	case *events.Connected:
 		if botClient.Store != nil && botClient.Store.ID != nil {
 			fmt.Printf("🟢 [ONLINE] Bot %s connected!\n", botClient.Store.ID.User)
@@ -155,8 +161,10 @@ func isKnownCommand(text string) bool {
 		"menu", "help", "list", "ping", "id", "owner", "data", "listbots",
 		"alwaysonline", "autoread", "autoreact", "autostatus", "statusreact",
 		"addstatus", "delstatus", "liststatus", "readallstatus", "setprefix", "mode",
-		"antilink", "antipic", "antivideo", "antisticker",
-		"kick", "add", "promote", "demote", "tagall", "hidetag", "group", "del", "delete",
+		"security", "antilink", "antipic", "antivideo", "antisticker", "antidoc", "antivoice", "antipoll",
+		"anticontact", "antilocation", "antiviewonce", "antiapk", "antiforward", "banmedia",
+		"kick", "add", "promote", "demote", "tagall", "hidetag", "group", "del", "delete", "nightmode", "slowmode", "raid", "adminguard", "groupinfo", "refreshgroup", "gban", "ungban", "xspam", "appeal",
+		"welcome", "setwelcome", "setbye", "setkick", "setpromote", "setdemote", "rules", "warn", "top", "inactive", "prune", "poll", "save", "notes", "clear", "settings", "bc", "broadcast", "invite",
 		"tiktok", "tt", "fb", "facebook", "insta", "ig", "pin", "pinterest", "ytmp3", "ytmp4",
 		"sticker", "s", "toimg", "tovideo", "removebg", "remini", "tourl", "weather", "translate", "tr", "vv",
 	}
@@ -210,8 +218,29 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 	bodyRaw := getText(v.Message)
 	isAudio := v.Message.GetAudioMessage() != nil // 🔥 Check if it's Audio
 
+	// 🗳️ پول ووٹ (انکرپٹڈ، عام میسج نہیں)
+	if v.Message.GetPollUpdateMessage() != nil {
+		go recordPollVote(client, v)
+		return
+	}
+
+	// 🛡️ گروپ گارڈز (گلوبل بین وغیرہ) سب سے پہلے
+	if runGroupGuards(client, v) {
+		return
+	}
+
+	// 📊 ممبر ایکٹیویٹی (.top / .inactive)
+	if v.Info.IsGroup && !v.Info.IsFromMe {
+		go trackActivity(client, v)
+	}
+
 	// 🛑 CRITICAL FIX: اگر ٹیکسٹ خالی ہے لیکن آڈیو ہے، تو اسے مت روکو!
 	if bodyRaw == "" && !isAudio {
+		// 🛡️ بغیر کیپشن والا میڈیا (اسٹیکر، پول، کانٹیکٹ...) بھی میڈیا رولز سے گزرے
+		if v.Info.IsGroup && !v.Info.IsFromMe && len(detectMediaTypes(v)) > 0 {
+			go checkSecurity(client, v)
+			return
+		}
 		if v.Info.Chat.String() != "status@broadcast" {
 			return // صرف تب روکو جب نہ ٹیکسٹ ہو اور نہ آڈیو
 		}
@@ -233,22 +262,6 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 		return
 	}
 
-	// =========================================================
-	// 🛡️ 4. IMMEDIATE ANTI-BUG PROTECTION (Private Chats Only)
-	// =========================================================
-	if AntiBugEnabled && !v.Info.IsGroup {
-		badChars := []string{"\u200b", "\u202e", "\u202d", "\u2060", "\u200f"}
-		totalJunk := 0
-		for _, char := range badChars {
-			totalJunk += strings.Count(bodyClean, char)
-		}
-		if totalJunk > 50 {
-			fmt.Printf("🛡️ MALICIOUS BUG DETECTED in DM! From: %s | Cleaning...\n", v.Info.Sender.User)
-			client.RevokeMessage(context.Background(), v.Info.Chat, v.Info.ID)
-			return
-		}
-	}
-
 	// 🟢 Variables Extraction
 	chatID := v.Info.Chat.String()
 	senderID := v.Info.Sender.ToNonAD().String()
@@ -351,10 +364,22 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 			qID := extMsg.ContextInfo.GetStanzaID()
 
 			// a. Setup Wizard
-			if _, ok := setupMap[qID]; ok {
+			if hasSetupSession(qID) {
 				handleSetupResponse(client, v)
 				return
 			}
+
+			// a2. Security Dashboard
+			if hasDashboardSession(qID) {
+				handleDashboardReply(client, v, qID)
+				return
+			}
+
+			// a3. Appeal Card (approve / deny)
+			if isAppealCard(qID) {
+				handleAppealReply(client, v, qID)
+				return
+			}
 			
 			// b. YouTube Search Selection
 			if session, ok := ytCache[qID]; ok {
@@ -426,6 +451,13 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 			}
 		}
 
+		// 📝 #name → گروپ نوٹ
+		if !isCommand && v.Info.IsGroup && strings.HasPrefix(bodyClean, "#") && canExecute(client, v, "get") {
+			if handleNoteHashtag(client, v, bodyClean) {
+				return
+			}
+		}
+
 		// ⚡ D. SECURITY CHECKS (OPTIMIZED)
 		if !isCommand && v.Info.IsGroup {
 			hasLink := false
@@ -462,11 +494,10 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 				}
 			}
 
-			isImage := v.Message.ImageMessage != nil
-			isVideo := v.Message.VideoMessage != nil
-			isSticker := v.Message.StickerMessage != nil
+			mediaTypes := detectMediaTypes(v)
+			isForwarded, _ := isForwardedMessage(v)
 
-			if !hasLink && !isImage && !isVideo && !isSticker {
+			if !hasLink && len(mediaTypes) == 0 && !isForwarded {
 				return
 			}
 
@@ -475,9 +506,8 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 
 			shouldCheck := false
 			if hasLink && s.Antilink { shouldCheck = true }
-			if isImage && s.AntiPic { shouldCheck = true }
-			if isVideo && s.AntiVideo { shouldCheck = true }
-			if isSticker && s.AntiSticker { shouldCheck = true }
+			if _, r := matchMediaRule(s, v); r != nil { shouldCheck = true }
+			if ok, _ := matchAntiForward(s, v); ok { shouldCheck = true }
 
 			if shouldCheck {
 				checkSecurity(client, v)
@@ -540,21 +570,70 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 		// ✅ WELCOME TOGGLE
 		case "welcome", "wel":
 			react(client, v.Info.Chat, v.Info.ID, "👋")
-			if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
-				replyMessage(client, v, "❌ Only Admins!")
-				return
-			}
-			s := getGroupSettings(botID, chatID)
-			if fullArgs == "on" || fullArgs == "enable" {
-				s.Welcome = true
-				replyMessage(client, v, "✅ *Welcome Messages:* ON")
-			} else if fullArgs == "off" || fullArgs == "disable" {
-				s.Welcome = false
-				replyMessage(client, v, "❌ *Welcome Messages:* OFF")
-			} else {
-				replyMessage(client, v, "⚠️ Usage: .welcome on | off")
-			}
-			saveGroupSettings(botID, s)
+			handleWelcomeCmd(client, v, args)
+
+		case "setwelcome", "setbye", "setkick", "setpromote", "setdemote":
+			react(client, v.Info.Chat, v.Info.ID, "✏️")
+			handleSetGreeting(client, v, strings.TrimPrefix(cmd, "set"))
+
+		case "rules":
+			react(client, v.Info.Chat, v.Info.ID, "📜")
+			handleRules(client, v, args)
+
+		case "warn":
+			react(client, v.Info.Chat, v.Info.ID, "⚠️")
+			handleWarn(client, v, args)
+
+		case "top":
+			react(client, v.Info.Chat, v.Info.ID, "🏆")
+			handleTop(client, v, args)
+
+		case "inactive":
+			react(client, v.Info.Chat, v.Info.ID, "💤")
+			handleInactive(client, v, args)
+
+		case "prune":
+			react(client, v.Info.Chat, v.Info.ID, "✂️")
+			handlePrune(client, v, args)
+
+		case "poll":
+			react(client, v.Info.Chat, v.Info.ID, "📊")
+			handlePoll(client, v, args)
+
+		case "pollresult", "pollresults":
+			react(client, v.Info.Chat, v.Info.ID, "📊")
+			handlePollResult(client, v)
+
+		case "pollclose":
+			react(client, v.Info.Chat, v.Info.ID, "🔒")
+			handlePollClose(client, v)
+
+		case "save":
+			react(client, v.Info.Chat, v.Info.ID, "📝")
+			handleSaveNote(client, v, args)
+
+		case "get":
+			handleGetNote(client, v, args)
+
+		case "notes":
+			react(client, v.Info.Chat, v.Info.ID, "📝")
+			handleListNotes(client, v)
+
+		case "clear":
+			react(client, v.Info.Chat, v.Info.ID, "🗑️")
+			handleClearNote(client, v, args)
+
+		case "settings":
+			react(client, v.Info.Chat, v.Info.ID, "⚙️")
+			handleSettingsTransfer(client, v, args)
+
+		case "bc", "broadcast":
+			react(client, v.Info.Chat, v.Info.ID, "📣")
+			handleBroadcast(client, v, args)
+
+		case "invite", "invitelink":
+			react(client, v.Info.Chat, v.Info.ID, "🔗")
+			handleInvite(client, v, args)
 
 		case "setprefix":
 			react(client, v.Info.Chat, v.Info.ID, "🔧")
@@ -644,7 +723,7 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 		
 		case "antibug":
 			react(client, v.Info.Chat, v.Info.ID, "🛡️")
-			handleAntiBug(client, v)
+			handleAntiBug(client, v, words[1:])
 		
 		case "send":
 			react(client, v.Info.Chat, v.Info.ID, "📤")
@@ -666,6 +745,10 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 			react(client, v.Info.Chat, v.Info.ID, "🤔")
 			HandleButtonCommands(client, v)
 		
+		case "security", "sec":
+			react(client, v.Info.Chat, v.Info.ID, "🛡️")
+			handleSecurityDashboard(client, v)
+
 		case "antilink":
 			react(client, v.Info.Chat, v.Info.ID, "🛡️")
 			startSecuritySetup(client, v, args, "antilink")
@@ -681,6 +764,18 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 		case "antisticker":
 			react(client, v.Info.Chat, v.Info.ID, "🚫")
 			startSecuritySetup(client, v, args, "antisticker")
+
+		case "banmedia":
+			react(client, v.Info.Chat, v.Info.ID, "🚫")
+			handleBanMedia(client, v, words[1:])
+
+		case "antiforward":
+			react(client, v.Info.Chat, v.Info.ID, "↪️")
+			startSecuritySetup(client, v, args, "antiforward")
+
+		case "antidoc", "antivoice", "antipoll", "anticontact", "antilocation", "antiviewonce", "antiapk":
+			react(client, v.Info.Chat, v.Info.ID, mediaRuleDefByCommand(cmd).Emoji)
+			startSecuritySetup(client, v, args, cmd)
 		
 		case "kick":
 			react(client, v.Info.Chat, v.Info.ID, "👢")
@@ -714,6 +809,62 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 			react(client, v.Info.Chat, v.Info.ID, "🗑️")
 			handleDelete(client, v)
 
+		case "modlog":
+			react(client, v.Info.Chat, v.Info.ID, "📒")
+			handleModLog(client, v, words[1:])
+
+		case "adminguard", "antitakeover":
+			react(client, v.Info.Chat, v.Info.ID, "🛡️")
+			handleAdminGuard(client, v, words[1:])
+
+		case "refreshgroup", "reloadgroup":
+			react(client, v.Info.Chat, v.Info.ID, "🔄")
+			handleRefreshGroup(client, v)
+
+		case "groupinfo", "infoguard":
+			react(client, v.Info.Chat, v.Info.ID, "🏷️")
+			handleGroupInfoCmd(client, v, words[1:])
+
+		case "slowmode", "slow":
+			react(client, v.Info.Chat, v.Info.ID, "🐢")
+			handleSlowMode(client, v, words[1:])
+
+		case "raid", "antiraid":
+			react(client, v.Info.Chat, v.Info.ID, "🚨")
+			handleRaid(client, v, words[1:])
+
+		case "appeal":
+			react(client, v.Info.Chat, v.Info.ID, "📝")
+			handleAppeal(client, v, words[1:])
+
+		case "appeals":
+			react(client, v.Info.Chat, v.Info.ID, "📝")
+			handleAppealsAdmin(client, v, words[1:])
+
+		case "xspam", "crossspam":
+			react(client, v.Info.Chat, v.Info.ID, "🧹")
+			handleXSpam(client, v, words[1:])
+
+		case "gban":
+			react(client, v.Info.Chat, v.Info.ID, "🚫")
+			handleGBan(client, v, words[1:])
+
+		case "ungban":
+			react(client, v.Info.Chat, v.Info.ID, "✅")
+			handleUnGBan(client, v, words[1:])
+
+		case "gbans", "gbanlist":
+			react(client, v.Info.Chat, v.Info.ID, "📋")
+			handleGBanList(client, v)
+
+		case "nightmode", "night":
+			react(client, v.Info.Chat, v.Info.ID, "🌙")
+			handleNightMode(client, v, words[1:])
+
+		case "requests", "joinreq":
+			react(client, v.Info.Chat, v.Info.ID, "📥")
+			handleJoinRequests(client, v, words[1:])
+
 		// 🛠️ HEAVY MEDIA COMMANDS
 		case "toimg":
 			react(client, v.Info.Chat, v.Info.ID, "🖼️")
@@ -1065,56 +1216,9 @@ func isOwner(client *whatsmeow.Client, sender types.JID) bool {
 	return senderLID == botLID
 }
 
-// ⚡ ایڈمن کیشے (تاکہ بار بار واٹس ایپ سرور کو کال نہ جائے)
-type AdminCache struct {
-	Admins    map[string]bool
-	ExpiresAt time.Time
-}
-
-var adminCacheMap = make(map[string]*AdminCache)
-var adminMutex sync.RWMutex
-
+// ⚡ ایڈمن چیک اب ایونٹ سے اپڈیٹ ہونے والے پارٹیسپنٹ کیشے سے (participant_cache.go)
 func isAdmin(client *whatsmeow.Client, chat, user types.JID) bool {
-	chatID := chat.String()
-	userClean := getCleanID(user.User)
-
-	// 1. پہلے کیشے چیک کریں (Fastest)
-	adminMutex.RLock()
-	cache, exists := adminCacheMap[chatID]
-	adminMutex.RUnlock()
-
-	if exists && time.Now().Before(cache.ExpiresAt) {
-		return cache.Admins[userClean]
-	}
-
-	// ⚡ FIX: یہاں ہم نے ٹائم آؤٹ لگایا ہے (صرف 10 سیکنڈ انتظار کرے گا)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	info, err := client.GetGroupInfo(ctx, chat)
-	if err != nil {
-		fmt.Println("⚠️ Admin check timed out or failed:", err)
-		return false // اگر فیل ہو جائے تو سیفٹی کے لیے false
-	}
-
-	// 3. نئی لسٹ بنائیں
-	newAdmins := make(map[string]bool)
-	for _, p := range info.Participants {
-		if p.IsAdmin || p.IsSuperAdmin {
-			cleanP := getCleanID(p.JID.User)
-			newAdmins[cleanP] = true
-		}
-	}
-
-	// 4. کیشے میں محفوظ کریں (ٹائم بڑھا کر 24 گھنٹے کر دیں تاکہ بار بار چیک نہ کرے)
-	adminMutex.Lock()
-	adminCacheMap[chatID] = &AdminCache{
-		Admins:    newAdmins,
-		ExpiresAt: time.Now().Add(24 * time.Hour), // 5 گھنٹے سے بڑھا کر 24 گھنٹے کر دیا
-	}
-	adminMutex.Unlock()
-
-	return newAdmins[userClean]
+	return rosterIsAdmin(client, chat, user)
 }
 
 
@@ -1279,23 +1383,55 @@ func sendMenu(client *whatsmeow.Client, v *events.Message) {
  ╰───────────────╯
 
  ╭── 🛡️ 𝐆𝐫𝐨𝐮𝐩 𝐒𝐚𝐟𝐞𝐭𝐲 🛡️ ──╮
+ │ ❥ *%ssecurity* - Security Dashboard
  │ ❥ *%santilink* - Ban Links
  │ ❥ *%santipic* - Ban Images
  │ ❥ *%santivideo* - Ban Videos
  │ ❥ *%santisticker* - Ban Stickers
+ │ ❥ *%santidoc* - Ban Documents
+ │ ❥ *%santivoice* - Ban Voice Notes
+ │ ❥ *%santipoll* - Ban Polls
+ │ ❥ *%santicontact* - Ban Contacts
+ │ ❥ *%santilocation* - Ban Locations
+ │ ❥ *%santiviewonce* - Ban View-Once
+ │ ❥ *%santiapk* - Ban APK Files
+ │ ❥ *%santiforward* - Ban Forwards
+ │ ❥ *%sbanmedia* - Block Media (reply)
+ │ ❥ *%santibug* - Crash-Text Shield
+ │ ❥ *%sraid* - Anti-Raid Lockdown
+ │ ❥ *%sadminguard* - Anti-Takeover
+ │ ❥ *%sgroupinfo* - Name/Icon Guard
+ │ ❥ *%srefreshgroup* - Reload Members
+ │ ❥ *%sappeals* - Appeal Settings
  │ ❥ *%smode* - Admin/Public
- │ ❥ *%swelcome* - Auto Welcome
+ │ ❥ *%swelcome* - Greetings (on/off/test)
+ │ ❥ *%ssetwelcome* - Welcome/Bye Text
  ╰───────────────╯
 
  ╭── 🏰 𝐀𝐝𝐦𝐢𝐧 𝐏𝐨𝐰𝐞𝐫 🏰 ──╮
  │ ❥ *%skick* - Kick User
+ │ ❥ *%swarn* - Warn (rule <n>)
+ │ ❥ *%srules* - Group Rules
+ │ ❥ *%stop* - Activity Leaderboard
+ │ ❥ *%sinactive* - Silent Members
+ │ ❥ *%sprune* - Remove Inactive
+ │ ❥ *%spoll* - Create Poll
+ │ ❥ *%spollresult* - Poll Results
+ │ ❥ *%ssave* - Save Note (#name)
+ │ ❥ *%snotes* - List Notes
+ │ ❥ *%ssettings* - Export/Import/Clone
+ │ ❥ *%sinvite* - Link Rotation/Stats
  │ ❥ *%sadd* - Add User
  │ ❥ *%spromote* - Make Admin
  │ ❥ *%sdemote* - Remove Admin
  │ ❥ *%stagall* - Tag Everyone
  │ ❥ *%shidetag* - Ghost Tag
  │ ❥ *%sgroup* - Open/Close
+ │ ❥ *%snightmode* - Night Auto-Close
+ │ ❥ *%sslowmode* - 30s / off
  │ ❥ *%sdel* - Delete Msg
+ │ ❥ *%srequests* - Join Requests
+ │ ❥ *%smodlog* - Mod Actions Log
  │ ❥ *%svv* - Anti ViewOnce
  │ ❥ *%santidelete* - Anti Delete
  ╰───────────────╯
@@ -1310,6 +1446,7 @@ func sendMenu(client *whatsmeow.Client, v *events.Message) {
 
  ╭── 👑 𝐌𝐲 𝐊𝐢𝐧𝐠𝐝𝐨𝐦 👑 ──╮
  │ ❥ *%ssetprefix* - Change Prefix
+ │ ❥ *%sbc* - Broadcast to Groups
  │ ❥ *%salwaysonline* - Always On
  │ ❥ *%sautoread* - Auto Seen
  │ ❥ *%sautoreact* - Auto Like
@@ -1317,6 +1454,10 @@ func sendMenu(client *whatsmeow.Client, v *events.Message) {
  │ ❥ *%sstatusreact* - Status Like
  │ ❥ *%slistbots* - Active Bots
  │ ❥ *%sstats* - System Power
+ │ ❥ *%sgban* - Global Ban
+ │ ❥ *%sungban* - Remove Global Ban
+ │ ❥ *%sgbans* - Global Ban List
+ │ ❥ *%sxspam* - Cross-Group Spam
  ╰───────────────╯
 
       💖 𝐌𝐚𝐝𝐞 𝐖𝐢𝐭𝐡 𝐋𝐨𝐯𝐞 💖
@@ -1333,13 +1474,13 @@ func sendMenu(client *whatsmeow.Client, v *events.Message) {
 		// Editing
 		p, p, p, p, p, p, p, p,
 		// Group Safety
-		p, p, p, p, p, p,
+		p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p,
 		// Admin
-		p, p, p, p, p, p, p, p, p, p,
+		p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p, p,
 		// Private (SMS/TCS)
 		p, p, p, p, p,
 		// Owner
-		p, p, p, p, p, p, p, p,
+		p, p, p, p, p, p, p, p, p, p, p, p, p,
 	)
 
 	// 🔥 رپلائی اور چینل کی معلومات کا سیٹ اپ (Logic Same)
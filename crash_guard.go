@@ -0,0 +1,406 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ==================== اینٹی بگ (کریش ٹیکسٹ) اسکینر ====================
+// پوشیدہ/RTL کنٹرول کیریکٹرز، بہت لمبا ٹیکسٹ، ہزاروں مینشنز، گہرے کوٹڈ میسجز، بڑے vCard/لوکیشن
+// DM کے لیے بوٹ لیول سوئچ، گروپ کے لیے GroupSettings.CrashGuard
+// پکڑا گیا تو: ڈیلیٹ (گروپ میں)، محفوظ اقتباس مود لاگ میں، سینڈر بلاک
+
+const crashGuardConfigPrefix = "crashguard:config:"
+
+// CrashGuardConfig ہر بوٹ کی سیٹنگز اور حدیں
+type CrashGuardConfig struct {
+	DMs             bool    `json:"dms"`   // پرائیویٹ چیٹس اسکین ہوں
+	Block           bool    `json:"block"` // سینڈر بلاک کریں
+	MaxLength       int     `json:"max_length"`
+	InvisibleMin    int     `json:"invisible_min"`   // کم از کم اتنے پوشیدہ کیریکٹرز ہوں تو ریشو چیک
+	InvisibleRatio  float64 `json:"invisible_ratio"` // 0.3 = 30%
+	MaxBidi         int     `json:"max_bidi"`
+	MaxMarkRun      int     `json:"max_mark_run"` // ایک حرف پر لگاتار کمبائننگ مارکس (زالگو)
+	MaxMentions     int     `json:"max_mentions"`
+	MaxQuoteDepth   int     `json:"max_quote_depth"`
+	MaxVCardBytes   int     `json:"max_vcard_bytes"`
+	MaxLocationText int     `json:"max_location_text"`
+}
+
+func defaultCrashGuardConfig() *CrashGuardConfig {
+	return &CrashGuardConfig{
+		Block:           true,
+		MaxLength:       20000,
+		InvisibleMin:    30,
+		InvisibleRatio:  0.3,
+		MaxBidi:         20,
+		MaxMarkRun:      10,
+		MaxMentions:     300,
+		MaxQuoteDepth:   4,
+		MaxVCardBytes:   10000,
+		MaxLocationText: 1000,
+	}
+}
+
+func getCrashGuardConfig(botID string) *CrashGuardConfig {
+	cfg := defaultCrashGuardConfig()
+	if rdb == nil {
+		return cfg
+	}
+	if val, err := rdb.Get(ctx, crashGuardConfigPrefix+botID).Result(); err == nil {
+		json.Unmarshal([]byte(val), cfg)
+	}
+	return cfg
+}
+
+func saveCrashGuardConfig(botID string, cfg *CrashGuardConfig) {
+	if rdb == nil {
+		return
+	}
+	payload, _ := json.Marshal(cfg)
+	rdb.Set(ctx, crashGuardConfigPrefix+botID, payload, 0)
+}
+
+// سمت بدلنے والے کنٹرول کیریکٹرز
+func isBidiControl(r rune) bool {
+	switch {
+	case r == 0x200E, r == 0x200F, r == 0x061C:
+		return true
+	case r >= 0x202A && r <= 0x202E:
+		return true
+	case r >= 0x2066 && r <= 0x2069:
+		return true
+	}
+	return false
+}
+
+// نظر نہ آنے والے کیریکٹرز (زیرو وڈتھ، BOM، فلر)
+// کمبائننگ مارکس یہاں نہیں: اعراب اور ہندی ماترائیں عام ٹیکسٹ کا حصہ ہیں، ان کے لیے MaxMarkRun
+func isInvisibleRune(r rune) bool {
+	switch {
+	case r >= 0x200B && r <= 0x200D, r >= 0x2060 && r <= 0x2064:
+		return true
+	case r == 0xFEFF, r == 0x180E, r == 0x034F, r == 0x115F, r == 0x1160, r == 0x3164, r == 0xFFA0:
+		return true
+	}
+	return false
+}
+
+// میسج کا ContextInfo (مینشنز / کوٹڈ میسج کے لیے)
+func messageContextInfo(m *waProto.Message) *waProto.ContextInfo {
+	if m == nil {
+		return nil
+	}
+	switch {
+	case m.ExtendedTextMessage != nil:
+		return m.ExtendedTextMessage.GetContextInfo()
+	case m.ImageMessage != nil:
+		return m.ImageMessage.GetContextInfo()
+	case m.VideoMessage != nil:
+		return m.VideoMessage.GetContextInfo()
+	case m.DocumentMessage != nil:
+		return m.DocumentMessage.GetContextInfo()
+	case m.AudioMessage != nil:
+		return m.AudioMessage.GetContextInfo()
+	case m.StickerMessage != nil:
+		return m.StickerMessage.GetContextInfo()
+	case m.ContactMessage != nil:
+		return m.ContactMessage.GetContextInfo()
+	case m.ContactsArrayMessage != nil:
+		return m.ContactsArrayMessage.GetContextInfo()
+	case m.LocationMessage != nil:
+		return m.LocationMessage.GetContextInfo()
+	}
+	return nil
+}
+
+// 🔍 ہیورسٹکس: خالی = محفوظ، ورنہ وجہ
+func scanCrashPayload(cfg *CrashGuardConfig, m *waProto.Message) string {
+	if m == nil {
+		return ""
+	}
+
+	// A. ٹیکسٹ (کیپشن اور فائل نام بھی)
+	text := getText(m)
+	if doc := m.GetDocumentMessage(); doc != nil {
+		text += doc.GetFileName() + doc.GetCaption()
+	}
+	if text != "" {
+		total, invisible, bidi, markRun, maxMarkRun := 0, 0, 0, 0, 0
+		for _, r := range text {
+			total++
+			if unicode.Is(unicode.Mn, r) {
+				markRun++
+				maxMarkRun = max(maxMarkRun, markRun)
+				continue
+			}
+			markRun = 0
+			if isBidiControl(r) {
+				bidi++
+			} else if isInvisibleRune(r) {
+				invisible++
+			}
+		}
+		if total > cfg.MaxLength {
+			return fmt.Sprintf("Extreme length (%d chars)", total)
+		}
+		if bidi > cfg.MaxBidi {
+			return fmt.Sprintf("RTL/bidi control flood (%d)", bidi)
+		}
+		if invisible >= cfg.InvisibleMin && float64(invisible)/float64(total) >= cfg.InvisibleRatio {
+			return fmt.Sprintf("Invisible characters %d/%d", invisible, total)
+		}
+		if cfg.MaxMarkRun > 0 && maxMarkRun > cfg.MaxMarkRun {
+			return fmt.Sprintf("Stacked combining marks (%d on one letter)", maxMarkRun)
+		}
+	}
+
+	// B. مینشنز اور کوٹڈ میسجز کی گہرائی
+	ci := messageContextInfo(m)
+	if ci != nil && len(ci.GetMentionedJID()) > cfg.MaxMentions {
+		return fmt.Sprintf("Huge mention list (%d)", len(ci.GetMentionedJID()))
+	}
+	depth := 0
+	for q := ci.GetQuotedMessage(); q != nil; q = messageContextInfo(q).GetQuotedMessage() {
+		depth++
+		if depth > cfg.MaxQuoteDepth {
+			return fmt.Sprintf("Nested quotes deeper than %d", cfg.MaxQuoteDepth)
+		}
+	}
+
+	// C. vCard اور لوکیشن
+	vcardBytes := len(m.GetContactMessage().GetVcard())
+	for _, c := range m.GetContactsArrayMessage().GetContacts() {
+		vcardBytes += len(c.GetVcard())
+	}
+	if vcardBytes > cfg.MaxVCardBytes {
+		return fmt.Sprintf("Oversized vCard (%d bytes)", vcardBytes)
+	}
+	if loc := m.GetLocationMessage(); loc != nil {
+		n := len(loc.GetName()) + len(loc.GetAddress()) + len(loc.GetComment()) + len(loc.GetURL())
+		if n > cfg.MaxLocationText {
+			return fmt.Sprintf("Oversized location (%d bytes)", n)
+		}
+	}
+	return ""
+}
+
+// 🧪 لاگ کے لیے محفوظ اقتباس (کنٹرول کیریکٹرز کوڈ میں بدل دیے تاکہ لاگ خود کریش نہ کرے)
+func quarantineExcerpt(m *waProto.Message) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range messageExcerptRaw(m) {
+		if n >= modLogExcerptLen {
+			b.WriteString("…")
+			break
+		}
+		if isBidiControl(r) || isInvisibleRune(r) {
+			b.WriteString(fmt.Sprintf("<U+%04X>", r))
+		} else {
+			b.WriteRune(r)
+		}
+		n++
+	}
+	return b.String()
+}
+
+func messageExcerptRaw(m *waProto.Message) string {
+	if text := getText(m); text != "" {
+		return text
+	}
+	switch {
+	case m.GetContactMessage() != nil || m.GetContactsArrayMessage() != nil:
+		return "[vcard]"
+	case m.GetLocationMessage() != nil:
+		return "[location] " + m.GetLocationMessage().GetName()
+	}
+	return messageExcerpt(m)
+}
+
+// 🛡️ گارڈ: true = خطرناک میسج، روک دیا (DM اور گروپ دونوں)
+func enforceCrashGuard(client *whatsmeow.Client, v *events.Message) bool {
+	if client.Store.ID == nil {
+		return false
+	}
+	botID := getCleanID(client.Store.ID.User)
+	if v.Info.IsGroup && !getGroupSettings(botID, v.Info.Chat.String()).CrashGuard {
+		return false
+	}
+	cfg := getCrashGuardConfig(botID)
+	if !v.Info.IsGroup && !cfg.DMs {
+		return false
+	}
+
+	reason := scanCrashPayload(cfg, v.Message)
+	if reason == "" || isOwner(client, v.Info.Sender) || isDeploymentBot(v.Info.Sender) {
+		return false
+	}
+	fmt.Printf("🛡️ [ANTIBUG] %s from %s in %s\n", reason, v.Info.Sender.User, v.Info.Chat)
+	// گروپ ایڈمنز کا صرف میسج ہٹے، بلاک کبھی نہیں (غلط پکڑ پر ایڈمن ہی بلاک نہ ہو جائے)
+	senderAdmin := v.Info.IsGroup && isAdmin(client, v.Info.Chat, v.Info.Sender)
+
+	go func() {
+		bg := context.Background()
+		action := "quarantine"
+		if v.Info.IsGroup {
+			client.SendMessage(bg, v.Info.Chat, client.BuildRevoke(v.Info.Chat, v.Info.Sender, v.Info.ID))
+		}
+		if cfg.Block && !senderAdmin {
+			if _, err := client.UpdateBlocklist(bg, v.Info.Sender.ToNonAD(), events.BlocklistChangeActionBlock); err != nil {
+				fmt.Println("❌ Block Failed:", err)
+			} else {
+				action = "block"
+			}
+		}
+		logModAction(ModLogEntry{
+			BotID:     botID,
+			GroupID:   v.Info.Chat.String(),
+			Actor:     "bot",
+			Target:    getCleanID(v.Info.Sender.User),
+			Action:    action,
+			Rule:      "antibug",
+			Reason:    reason,
+			Excerpt:   quarantineExcerpt(v.Message),
+			MessageID: v.Info.ID,
+			Auto:      true,
+		})
+		if v.Info.IsGroup {
+			sendPlainText(client, v.Info.Chat, fmt.Sprintf(`╔════════════════╗
+║ 🛡️ ANTI-BUG
+╠════════════════╣
+║ 🧨 Crash payload removed
+║ 👤 From: +%s
+║ 📌 %s
+╚════════════════╝`, getCleanID(v.Info.Sender.User), reason))
+		}
+	}()
+	return true
+}
+
+// ==================== .antibug ====================
+func handleAntiBug(client *whatsmeow.Client, v *events.Message, args []string) {
+	botID := getCleanID(client.Store.ID.User)
+	cfg := getCrashGuardConfig(botID)
+	owner := isOwner(client, v.Info.Sender)
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	// 👥 گروپ سوئچ ایڈمن بھی بدل سکتا ہے، باقی صرف اونر
+	if sub == "group" {
+		if !v.Info.IsGroup {
+			replyMessage(client, v, "❌ This command is for Groups only.")
+			return
+		}
+		if !owner && !isAdmin(client, v.Info.Chat, v.Info.Sender) {
+			replyMessage(client, v, "👮 Only Group Admins can use this command.")
+			return
+		}
+		s := getGroupSettings(botID, v.Info.Chat.String())
+		s.CrashGuard = len(args) > 1 && strings.ToLower(args[1]) == "on"
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "🛡️ Anti-Bug for this group: "+onOffText(s.CrashGuard))
+		return
+	}
+	if sub != "" && !owner {
+		replyMessage(client, v, "❌ Only Owner!")
+		return
+	}
+
+	switch sub {
+	case "on", "off":
+		cfg.DMs = sub == "on"
+		saveCrashGuardConfig(botID, cfg)
+		replyMessage(client, v, "🛡️ Anti-Bug for private chats: "+onOffText(cfg.DMs))
+
+	case "block":
+		cfg.Block = len(args) > 1 && strings.ToLower(args[1]) == "on"
+		saveCrashGuardConfig(botID, cfg)
+		replyMessage(client, v, "🛡️ Block sender on detection: "+onOffText(cfg.Block))
+
+	case "set":
+		if len(args) < 3 {
+			replyMessage(client, v, "⚠️ Usage: .antibug set <length|invisible|ratio|bidi|marks|mentions|quotes|vcard|location> <number>")
+			return
+		}
+		n, err := strconv.Atoi(args[2])
+		if err != nil || n < 1 {
+			replyMessage(client, v, "❌ Value must be a positive number.")
+			return
+		}
+		switch strings.ToLower(args[1]) {
+		case "length":
+			cfg.MaxLength = n
+		case "invisible":
+			cfg.InvisibleMin = n
+		case "ratio":
+			if n > 100 {
+				replyMessage(client, v, "❌ Ratio is a percent (1-100).")
+				return
+			}
+			cfg.InvisibleRatio = float64(n) / 100
+		case "bidi":
+			cfg.MaxBidi = n
+		case "marks":
+			cfg.MaxMarkRun = n
+		case "mentions":
+			cfg.MaxMentions = n
+		case "quotes":
+			cfg.MaxQuoteDepth = n
+		case "vcard":
+			cfg.MaxVCardBytes = n
+		case "location":
+			cfg.MaxLocationText = n
+		default:
+			replyMessage(client, v, "❌ Unknown limit: "+args[1])
+			return
+		}
+		saveCrashGuardConfig(botID, cfg)
+		replyMessage(client, v, fmt.Sprintf("✅ %s limit set to %d", strings.ToLower(args[1]), n))
+
+	case "reset":
+		def := defaultCrashGuardConfig()
+		def.DMs = cfg.DMs
+		saveCrashGuardConfig(botID, def)
+		replyMessage(client, v, "♻️ Anti-Bug limits reset to defaults.")
+
+	default:
+		groupLine := ""
+		if v.Info.IsGroup {
+			groupLine = "║ 👥 This Group: " + onOffText(getGroupSettings(botID, v.Info.Chat.String()).CrashGuard) + "\n"
+		}
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🛡️ ANTI-BUG SCANNER
+╠════════════════╣
+║ 💬 Private Chats: %s
+%s║ 🚫 Block Sender: %s
+╠════════════════╣
+║ 📏 Length: %d
+║ 👻 Invisible: %d+ & %.0f%%
+║ ↔️ Bidi Controls: %d
+║ 〰️ Marks per letter: %d
+║ 🏷️ Mentions: %d
+║ 💬 Quote Depth: %d
+║ 📇 vCard: %d bytes
+║ 📍 Location: %d bytes
+╠════════════════╣
+║ .antibug on/off
+║ .antibug group on/off
+║ .antibug block on/off
+║ .antibug set <limit> <n>
+║ .antibug reset
+╚════════════════╝`, onOffText(cfg.DMs), groupLine, onOffText(cfg.Block),
+			cfg.MaxLength, cfg.InvisibleMin, cfg.InvisibleRatio*100, cfg.MaxBidi, cfg.MaxMarkRun, cfg.MaxMentions,
+			cfg.MaxQuoteDepth, cfg.MaxVCardBytes, cfg.MaxLocationText))
+	}
+}
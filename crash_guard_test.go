@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestScanCrashPayload(t *testing.T) {
+	cfg := defaultCrashGuardConfig()
+	tests := []struct {
+		name string
+		text string
+		want string // خالی = محفوظ، ورنہ وجہ کا حصہ
+	}{
+		{"plain text", "Assalam o Alaikum, meeting at 5 pm", ""},
+		{"vowelled arabic", strings.Repeat("بِسْمِ ٱللَّٰهِ ٱلرَّحْمَٰنِ ٱلرَّحِيمِ ", 20), ""},
+		{"vowelled urdu", strings.Repeat("مُحَمَّدٌ رَسُولُ ٱللَّٰهِ، وَالسَّلَامُ عَلَیْکُمْ ", 20), ""},
+		{"hindi vowel signs", strings.Repeat("नमस्ते दुनिया, आप कैसे हैं? ", 20), ""},
+		{"zwsp flood", "hi" + strings.Repeat("\u200b", 500), "Invisible characters"},
+		{"zwsp inside words", strings.Repeat("a\u200b", 200), "Invisible characters"},
+		{"bidi flood", strings.Repeat("\u202e\u202d", 30) + "hello", "RTL/bidi control flood"},
+		{"stacked marks", "a" + strings.Repeat("\u0316\u0301", 20), "Stacked combining marks"},
+		{"extreme length", strings.Repeat("x", cfg.MaxLength+1), "Extreme length"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scanCrashPayload(cfg, &waProto.Message{Conversation: proto.String(tt.text)})
+			if tt.want == "" && got != "" {
+				t.Fatalf("expected safe, got %q", got)
+			}
+			if tt.want != "" && !strings.Contains(got, tt.want) {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
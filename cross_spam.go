@@ -0,0 +1,326 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ==================== کراس گروپ سپیم ====================
+// ہر میسج کا فنگر پرنٹ (نارملائزڈ ٹیکسٹ ہیش یا میڈیا FileSHA256)
+// ایک ہی سینڈر وہی چیز T منٹ میں N گروپس میں بھیجے تو ہر جگہ سے ڈیلیٹ + وارن/کک (+ آپشنل گلوبل بین)
+
+const (
+	xspamConfigPrefix = "xspam:config:"
+	xspamSeenPrefix   = "xspam:seen:"
+	xspamFlagPrefix   = "xspam:flag:"
+	xspamMinTextLen   = 15 // اس سے چھوٹے ٹیکسٹ ("hi", "ok") نظرانداز
+)
+
+// XSpamConfig ہر بوٹ کی سیٹنگ (اس کے تمام گروپس پر لاگو)
+type XSpamConfig struct {
+	Enabled   bool   `json:"enabled"`
+	Groups    int    `json:"groups"`     // N گروپس
+	WindowMin int    `json:"window_min"` // T منٹ
+	Action    string `json:"action"`     // delete / warn / kick
+	GBan      bool   `json:"gban"`
+}
+
+// xspamCopy ایک کاپی کا ریکارڈ (بعد میں ڈیلیٹ کرنے کے لیے)
+type xspamCopy struct {
+	Chat string    `json:"chat"`
+	ID   string    `json:"id"`
+	At   time.Time `json:"at"`
+}
+
+func getXSpamConfig(botID string) *XSpamConfig {
+	cfg := &XSpamConfig{Groups: 3, WindowMin: 10, Action: "warn"}
+	if rdb == nil {
+		return cfg
+	}
+	if val, err := rdb.Get(ctx, xspamConfigPrefix+botID).Result(); err == nil {
+		json.Unmarshal([]byte(val), cfg)
+	}
+	return cfg
+}
+
+func saveXSpamConfig(botID string, cfg *XSpamConfig) {
+	if rdb == nil {
+		return
+	}
+	payload, _ := json.Marshal(cfg)
+	rdb.Set(ctx, xspamConfigPrefix+botID, payload, 0)
+}
+
+// 📎 میڈیا کا FileSHA256 (نہ ہو تو nil)
+func mediaFileSHA256(msg *waProto.Message) []byte {
+	switch {
+	case msg.GetImageMessage() != nil:
+		return msg.GetImageMessage().GetFileSHA256()
+	case msg.GetVideoMessage() != nil:
+		return msg.GetVideoMessage().GetFileSHA256()
+	case msg.GetDocumentMessage() != nil:
+		return msg.GetDocumentMessage().GetFileSHA256()
+	case msg.GetAudioMessage() != nil:
+		return msg.GetAudioMessage().GetFileSHA256()
+	case msg.GetStickerMessage() != nil:
+		return msg.GetStickerMessage().GetFileSHA256()
+	}
+	return nil
+}
+
+// 🔎 فنگر پرنٹ: میڈیا ہو تو اس کا ہیش، ورنہ صرف حروف/ہندسوں والا ٹیکسٹ (اسپیس/ایموجی ٹرکس بے اثر)
+func spamFingerprint(v *events.Message) string {
+	if sum := mediaFileSHA256(v.Message); len(sum) > 0 {
+		return "m" + hex.EncodeToString(sum[:12])
+	}
+	var b strings.Builder
+	for _, r := range strings.ToLower(getText(v.Message)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	norm := b.String()
+	if len([]rune(norm)) < xspamMinTextLen {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(norm))
+	return "t" + hex.EncodeToString(sum[:12])
+}
+
+// 🛡️ گارڈ: true = میسج پہلے سے فلیگ شدہ سپیم ہے، روک دیا
+func checkCrossGroupSpam(client *whatsmeow.Client, v *events.Message) bool {
+	if rdb == nil || client.Store.ID == nil {
+		return false
+	}
+	botID := getCleanID(client.Store.ID.User)
+	cfg := getXSpamConfig(botID)
+	if !cfg.Enabled {
+		return false
+	}
+	fp := spamFingerprint(v)
+	if fp == "" || isOwner(client, v.Info.Sender) || isAdmin(client, v.Info.Chat, v.Info.Sender) {
+		return false
+	}
+
+	window := time.Duration(cfg.WindowMin) * time.Minute
+	sender := getCleanID(v.Info.Sender.User)
+	tail := botID + ":" + sender + ":" + fp
+	copyEntry := xspamCopy{Chat: v.Info.Chat.String(), ID: v.Info.ID, At: time.Now()}
+
+	// پہلے سے فلیگ شدہ: یہ نئی کاپی بھی فوراً
+	if rdb.Exists(ctx, xspamFlagPrefix+tail).Val() > 0 {
+		go punishCrossGroupSpam(client, cfg, v.Info.Sender, []xspamCopy{copyEntry}, false)
+		return true
+	}
+
+	payload, _ := json.Marshal(copyEntry)
+	key := xspamSeenPrefix + tail
+	rdb.RPush(ctx, key, payload)
+	rdb.Expire(ctx, key, window)
+
+	vals, _ := rdb.LRange(ctx, key, 0, -1).Result()
+	var copies []xspamCopy
+	chats := make(map[string]bool)
+	for _, val := range vals {
+		var c xspamCopy
+		if json.Unmarshal([]byte(val), &c) != nil || time.Since(c.At) > window {
+			continue
+		}
+		copies = append(copies, c)
+		chats[c.Chat] = true
+	}
+	if len(chats) < cfg.Groups {
+		return false
+	}
+
+	// ایک ہی بار ٹرگر
+	if ok, err := rdb.SetNX(ctx, xspamFlagPrefix+tail, 1, window).Result(); err != nil || !ok {
+		return true
+	}
+	rdb.Del(ctx, key)
+	fmt.Printf("🧹 [XSPAM] %s posted the same content in %d groups\n", sender, len(chats))
+	go punishCrossGroupSpam(client, cfg, v.Info.Sender, copies, true)
+	return true
+}
+
+// ⚖️ تمام کاپیز ڈیلیٹ، پھر ہر گروپ میں وارن/کک
+func punishCrossGroupSpam(client *whatsmeow.Client, cfg *XSpamConfig, sender types.JID, copies []xspamCopy, first bool) {
+	botID := getCleanID(client.Store.ID.User)
+	bg := context.Background()
+	reason := fmt.Sprintf("Same content in %d+ groups within %d min", cfg.Groups, cfg.WindowMin)
+
+	byChat := make(map[string][]string)
+	for _, c := range copies {
+		byChat[c.Chat] = append(byChat[c.Chat], c.ID)
+	}
+
+	for chatStr, ids := range byChat {
+		chat, err := types.ParseJID(chatStr)
+		if err != nil || !botIsGroupAdmin(client, chat) {
+			continue
+		}
+		for _, id := range ids {
+			client.SendMessage(bg, chat, client.BuildRevoke(chat, sender, id))
+			logModAction(ModLogEntry{
+				BotID: botID, GroupID: chatStr, Actor: "bot", Target: getCleanID(sender.User),
+				Action: "delete", Rule: "xspam", Reason: reason, MessageID: id, Auto: true,
+			})
+		}
+
+		action := "🚫 Deleted"
+		switch cfg.Action {
+		case "kick":
+			if _, err := client.UpdateGroupParticipants(bg, chat, []types.JID{sender}, whatsmeow.ParticipantChangeRemove); err == nil {
+				action = "👢 Kicked"
+				logModAction(ModLogEntry{
+					BotID: botID, GroupID: chatStr, Actor: "bot", Target: getCleanID(sender.User),
+					Action: "kick", Rule: "xspam", Reason: reason, Auto: true,
+				})
+			}
+		case "warn":
+			action = "⚠️ " + warnUserInGroup(client, botID, chat, sender, "xspam", reason)
+		}
+
+		sendPlainText(client, chat, fmt.Sprintf(`╔════════════════╗
+║ 🧹 CROSS-GROUP SPAM
+╠════════════════╣
+║ 👤 User: +%s
+║ 📌 %s
+║ %s
+╚════════════════╝`, getCleanID(sender.User), reason, action))
+	}
+
+	if first && cfg.GBan {
+		if _, err := addGlobalBan(client, sender, "Cross-group spam", "bot"); err == nil {
+			logModAction(ModLogEntry{
+				BotID: botID, Actor: "bot", Target: getCleanID(sender.User),
+				Action: "gban", Rule: "xspam", Reason: reason, Auto: true,
+			})
+			for chatStr := range byChat {
+				if chat, err := types.ParseJID(chatStr); err == nil {
+					kickGloballyBanned(client, chat, sender, "Globally banned: cross-group spam")
+				}
+			}
+		}
+	}
+}
+
+// ⚠️ گروپ وارننگ (3 پر کک)، نتیجے کا ٹیکسٹ واپس
+func warnUserInGroup(client *whatsmeow.Client, botID string, chat, user types.JID, rule, reason string) string {
+	return addWarning(client, botID, chat, user, "bot", rule, reason)
+}
+
+// وارننگ شمار کریں، 3/3 پر کک (actor = "bot" یا ایڈمن کا نمبر)
+func addWarning(client *whatsmeow.Client, botID string, chat, user types.JID, actor, rule, reason string) string {
+	s := getGroupSettings(botID, chat.String())
+	if s.Warnings == nil {
+		s.Warnings = make(map[string]int)
+	}
+	key := user.String()
+	s.Warnings[key]++
+	count := s.Warnings[key]
+	auto := actor == "bot"
+	logModAction(ModLogEntry{
+		BotID: botID, GroupID: chat.String(), Actor: actor, Target: getCleanID(user.User),
+		Action: "warn", Rule: rule, Reason: reason, Auto: auto,
+	})
+	if count >= 3 {
+		if _, err := client.UpdateGroupParticipants(context.Background(), chat, []types.JID{user}, whatsmeow.ParticipantChangeRemove); err == nil {
+			delete(s.Warnings, key)
+			saveGroupSettings(botID, s)
+			logModAction(ModLogEntry{
+				BotID: botID, GroupID: chat.String(), Actor: actor, Target: getCleanID(user.User),
+				Action: "kick", Rule: rule, Reason: reason + " (3/3 warnings)", Auto: auto,
+			})
+			go sendAppealHint(client, chat, user, reason+" (3/3 warnings)")
+			return "Kicked (3/3 warnings)"
+		}
+	}
+	saveGroupSettings(botID, s)
+	return fmt.Sprintf("Warning %d/3", count)
+}
+
+// ==================== .xspam ====================
+func handleXSpam(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Owner!")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	cfg := getXSpamConfig(botID)
+
+	sub, val := "", ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+	if len(args) > 1 {
+		val = strings.ToLower(args[1])
+	}
+
+	switch sub {
+	case "on", "off":
+		cfg.Enabled = sub == "on"
+		saveXSpamConfig(botID, cfg)
+		replyMessage(client, v, "✅ Cross-group spam detection "+strings.ToUpper(sub))
+
+	case "groups", "window":
+		n, err := strconv.Atoi(val)
+		min := 1
+		if sub == "groups" {
+			min = 2
+		}
+		if err != nil || n < min {
+			replyMessage(client, v, fmt.Sprintf("⚠️ Usage: .xspam %s <number>", sub))
+			return
+		}
+		if sub == "groups" {
+			cfg.Groups = n
+		} else {
+			cfg.WindowMin = n
+		}
+		saveXSpamConfig(botID, cfg)
+		replyMessage(client, v, fmt.Sprintf("✅ Trigger: same content in %d groups within %d min", cfg.Groups, cfg.WindowMin))
+
+	case "action":
+		if val != "delete" && val != "warn" && val != "kick" {
+			replyMessage(client, v, "⚠️ Usage: .xspam action delete/warn/kick")
+			return
+		}
+		cfg.Action = val
+		saveXSpamConfig(botID, cfg)
+		replyMessage(client, v, "✅ Action set to: "+strings.ToUpper(val))
+
+	case "gban":
+		cfg.GBan = val == "on"
+		saveXSpamConfig(botID, cfg)
+		replyMessage(client, v, "✅ Auto global ban: "+onOffText(cfg.GBan))
+
+	default:
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🧹 CROSS-GROUP SPAM
+╠════════════════╣
+║ Status: %s
+║ Trigger: %d groups / %d min
+║ Action: %s
+║ Auto GBan: %s
+╠════════════════╣
+║ .xspam on/off
+║ .xspam groups <n>
+║ .xspam window <min>
+║ .xspam action delete/warn/kick
+║ .xspam gban on/off
+╚════════════════╝`, onOffText(cfg.Enabled), cfg.Groups, cfg.WindowMin, strings.ToUpper(cfg.Action), onOffText(cfg.GBan)))
+	}
+}
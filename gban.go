@@ -0,0 +1,439 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ==================== گلوبل بین لسٹ ====================
+// ہر بوٹ کی اپنی لسٹ (gban:<bot>)، بین شدہ یوزر جوائن کرے یا میسج کرے تو نکال دیا جاتا ہے
+// دوسرے بوٹ کی لسٹ تبھی لاگو جب وہ share آن کرے اور یہ بوٹ اسے subscribe کرے
+// فیلڈ = صاف نمبر (اور LID ہو تو وہ بھی، تاکہ دونوں طرح پہچانا جائے)
+
+const (
+	gbanPrefix       = "gban:"           // gban:<bot> → ہیش
+	gbanSharedKey    = "gban:shared"     // جن بوٹس نے اپنی لسٹ شیئر کی
+	gbanSubscribeKey = "gban:subscribe:" // gban:subscribe:<bot> → جن بوٹس کی لسٹ یہ بوٹ مانتا ہے
+	gbanKickLock     = "gban:kick:"
+	gbanCacheTTL     = time.Minute
+)
+
+// GBanEntry ایک بین کا ریکارڈ
+type GBanEntry struct {
+	User   string    `json:"user"` // نمبر (یا LID اگر نمبر نہ ملے)
+	LID    string    `json:"lid,omitempty"`
+	Reason string    `json:"reason"`
+	By     string    `json:"by"`
+	BotID  string    `json:"bot_id"`
+	At     time.Time `json:"at"`
+}
+
+type gbanCacheEntry struct {
+	ids map[string]bool
+	at  time.Time
+}
+
+// ⚡ ہر میسج پر ریڈیس کال سے بچنے کے لیے RAM کیشے (ہر بوٹ کا الگ: اپنی + سبسکرائبڈ لسٹس)
+var (
+	gbanCache = make(map[string]*gbanCacheEntry)
+	gbanMu    sync.RWMutex
+)
+
+func gbanListKey(botID string) string {
+	return gbanPrefix + botID
+}
+
+// 🔗 اس بوٹ پر لاگو لسٹس: اپنی + وہ سبسکرائبڈ جو ابھی بھی شیئرڈ ہیں
+func gbanSourceBots(botID string) []string {
+	bots := []string{botID}
+	subs, _ := rdb.SMembers(ctx, gbanSubscribeKey+botID).Result()
+	for _, other := range subs {
+		if shared, _ := rdb.SIsMember(ctx, gbanSharedKey, other).Result(); shared && other != botID {
+			bots = append(bots, other)
+		}
+	}
+	return bots
+}
+
+func gbanCacheFor(botID string) map[string]bool {
+	gbanMu.RLock()
+	entry := gbanCache[botID]
+	gbanMu.RUnlock()
+	if entry != nil && time.Since(entry.at) < gbanCacheTTL {
+		return entry.ids
+	}
+	if rdb == nil {
+		return nil
+	}
+	m := make(map[string]bool)
+	for _, src := range gbanSourceBots(botID) {
+		keys, err := rdb.HKeys(ctx, gbanListKey(src)).Result()
+		if err != nil {
+			if entry != nil {
+				return entry.ids // ریڈیس عارضی طور پر نہ ملے تو پرانی لسٹ
+			}
+			return nil
+		}
+		for _, k := range keys {
+			m[k] = true
+		}
+	}
+	gbanMu.Lock()
+	gbanCache[botID] = &gbanCacheEntry{ids: m, at: time.Now()}
+	gbanMu.Unlock()
+	return m
+}
+
+// لسٹ یا شیئرنگ بدلی: اس پروسیس کے تمام بوٹس دوبارہ پڑھیں (دوسرے پروسیس TTL کے بعد)
+func invalidateGBanCache() {
+	gbanMu.Lock()
+	gbanCache = make(map[string]*gbanCacheEntry)
+	gbanMu.Unlock()
+}
+
+// یوزر کی تمام ممکنہ آئی ڈیز (LID اور فون) — بین، پروٹیکٹڈ لسٹس وغیرہ میں میچنگ کے لیے
+func userIDVariants(client *whatsmeow.Client, user types.JID) []string {
+	ids := []string{getCleanID(user.User)}
+	if user.Server == types.HiddenUserServer {
+		if phone := resolvePhoneNumber(client, user); phone != "" && phone != ids[0] {
+			ids = append(ids, phone)
+		}
+	} else if lid, err := client.Store.LIDs.GetLIDForPN(context.Background(), user.ToNonAD()); err == nil && !lid.IsEmpty() {
+		ids = append(ids, getCleanID(lid.User))
+	}
+	return ids
+}
+
+// 🚫 کیا یوزر گلوبل بین ہے؟
+func isGloballyBanned(client *whatsmeow.Client, user types.JID) bool {
+	if client.Store.ID == nil {
+		return false
+	}
+	banned := gbanCacheFor(getCleanID(client.Store.ID.User))
+	if len(banned) == 0 {
+		return false
+	}
+	for _, id := range userIDVariants(client, user) {
+		if banned[id] {
+			return true
+		}
+	}
+	return false
+}
+
+func addGlobalBan(client *whatsmeow.Client, user types.JID, reason, by string) (*GBanEntry, error) {
+	if rdb == nil {
+		return nil, fmt.Errorf("redis not connected")
+	}
+	ids := userIDVariants(client, user)
+	entry := &GBanEntry{
+		User:   ids[0],
+		Reason: reason,
+		By:     by,
+		BotID:  getCleanID(client.Store.ID.User),
+		At:     time.Now(),
+	}
+	if user.Server == types.HiddenUserServer {
+		entry.LID = ids[0]
+		if len(ids) > 1 {
+			entry.User = ids[1]
+		}
+	} else if len(ids) > 1 {
+		entry.LID = ids[1]
+	}
+	payload, _ := json.Marshal(entry)
+	for _, id := range ids {
+		if err := rdb.HSet(ctx, gbanListKey(entry.BotID), id, payload).Err(); err != nil {
+			return nil, err
+		}
+	}
+	invalidateGBanCache()
+	return entry, nil
+}
+
+func removeGlobalBan(client *whatsmeow.Client, user types.JID) bool {
+	if rdb == nil {
+		return false
+	}
+	key := gbanListKey(getCleanID(client.Store.ID.User))
+	ids := userIDVariants(client, user)
+	// ریکارڈ میں محفوظ دوسری آئی ڈی بھی ہٹائیں
+	for _, id := range ids {
+		if val, err := rdb.HGet(ctx, key, id).Result(); err == nil {
+			var e GBanEntry
+			if json.Unmarshal([]byte(val), &e) == nil {
+				ids = append(ids, e.User, e.LID)
+			}
+		}
+	}
+	var fields []string
+	for _, id := range ids {
+		if id != "" {
+			fields = append(fields, id)
+		}
+	}
+	n, _ := rdb.HDel(ctx, key, fields...).Result()
+	invalidateGBanCache()
+	return n > 0
+}
+
+// اپنی + سبسکرائبڈ لسٹس کی انٹریز (BotID سے پتا چلتا ہے کس کی ہے)
+func listGlobalBans(botID string) []GBanEntry {
+	if rdb == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var out []GBanEntry
+	for _, src := range gbanSourceBots(botID) {
+		all, err := rdb.HGetAll(ctx, gbanListKey(src)).Result()
+		if err != nil {
+			continue
+		}
+		for _, val := range all {
+			var e GBanEntry
+			if json.Unmarshal([]byte(val), &e) != nil || seen[e.User] {
+				continue
+			}
+			seen[e.User] = true
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].At.After(out[j].At) })
+	return out
+}
+
+// 👢 بین شدہ کو گروپ سے نکالیں (کئی بوٹس ہوں تو ایک ہی نکالے)
+func kickGloballyBanned(client *whatsmeow.Client, chat types.JID, user types.JID, reason string) {
+	lock := gbanKickLock + chat.String() + ":" + getCleanID(user.User)
+	if ok, err := rdb.SetNX(ctx, lock, 1, time.Minute).Result(); err != nil || !ok {
+		return
+	}
+	if !botIsGroupAdmin(client, chat) {
+		rdb.Del(ctx, lock)
+		return
+	}
+	if _, err := client.UpdateGroupParticipants(context.Background(), chat, []types.JID{user}, whatsmeow.ParticipantChangeRemove); err != nil {
+		fmt.Printf("⚠️ [GBAN] Kick failed in %s: %v\n", chat, err)
+		rdb.Del(ctx, lock)
+		return
+	}
+	logModAction(ModLogEntry{
+		BotID:   getCleanID(client.Store.ID.User),
+		GroupID: chat.String(),
+		Actor:   "bot",
+		Target:  getCleanID(user.User),
+		Action:  "kick",
+		Rule:    "gban",
+		Reason:  reason,
+		Auto:    true,
+	})
+	sendPlainText(client, chat, fmt.Sprintf("🚫 Globally banned user removed: +%s", getCleanID(user.User)))
+}
+
+// 📥 جوائن پر
+func enforceGlobalBansOnJoin(client *whatsmeow.Client, v *events.GroupInfo) {
+	for _, user := range v.Join {
+		if isGloballyBanned(client, user) {
+			kickGloballyBanned(client, v.JID, user, "Globally banned (joined)")
+		}
+	}
+}
+
+// 💬 میسج پر (true = میسج روک دیا)
+func enforceGlobalBanOnMessage(client *whatsmeow.Client, v *events.Message) bool {
+	if !isGloballyBanned(client, v.Info.Sender) {
+		return false
+	}
+	client.SendMessage(context.Background(), v.Info.Chat, client.BuildRevoke(v.Info.Chat, v.Info.Sender, v.Info.ID))
+	kickGloballyBanned(client, v.Info.Chat, v.Info.Sender, "Globally banned (posted)")
+	return true
+}
+
+// ==================== .gban / .ungban / .gbans ====================
+func handleGBan(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Owner!")
+		return
+	}
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case "share", "subscribe", "sub", "unsubscribe", "unsub":
+			handleGBanSharing(client, v, strings.ToLower(args[0]), args[1:])
+			return
+		}
+	}
+	target, rest := extractTargetJID(v, args)
+	if target.IsEmpty() {
+		replyMessage(client, v, "⚠️ Usage: .gban @user <reason> (or reply / number)")
+		return
+	}
+	reason := strings.TrimSpace(strings.Join(rest, " "))
+	if reason == "" {
+		reason = "No reason given"
+	}
+
+	entry, err := addGlobalBan(client, target, reason, getCleanID(v.Info.Sender.User))
+	if err != nil {
+		replyMessage(client, v, "❌ Failed: "+err.Error())
+		return
+	}
+	logModAction(ModLogEntry{
+		BotID:   getCleanID(client.Store.ID.User),
+		GroupID: v.Info.Chat.String(),
+		Actor:   getCleanID(v.Info.Sender.User),
+		Target:  entry.User,
+		Action:  "gban",
+		Rule:    "manual",
+		Reason:  reason,
+	})
+
+	shared := "No (.gban share on)"
+	if ok, _ := rdb.SIsMember(ctx, gbanSharedKey, entry.BotID).Result(); ok {
+		shared = "Yes, subscribed bots apply it"
+	}
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🚫 GLOBAL BAN
+╠════════════════╣
+║ 👤 User: %s
+║ 📌 Reason: %s
+║ 🤖 All groups of this bot
+║ 🔗 Shared: %s
+╚════════════════╝`, entry.User, reason, shared))
+
+	// موجودہ گروپ سے فوراً نکالیں
+	if v.Info.IsGroup {
+		go kickGloballyBanned(client, v.Info.Chat, target, "Globally banned: "+reason)
+	}
+}
+
+func handleUnGBan(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Owner!")
+		return
+	}
+	target, _ := extractTargetJID(v, args)
+	if target.IsEmpty() {
+		replyMessage(client, v, "⚠️ Usage: .ungban @user (or reply / number)")
+		return
+	}
+	if !removeGlobalBan(client, target) {
+		replyMessage(client, v, "ℹ️ User is not globally banned.")
+		return
+	}
+	logModAction(ModLogEntry{
+		BotID:   getCleanID(client.Store.ID.User),
+		GroupID: v.Info.Chat.String(),
+		Actor:   getCleanID(v.Info.Sender.User),
+		Target:  getCleanID(target.User),
+		Action:  "ungban",
+		Rule:    "manual",
+		Reason:  ".ungban command",
+	})
+	replyMessage(client, v, "✅ Global ban removed: "+getCleanID(target.User))
+}
+
+func handleGBanList(client *whatsmeow.Client, v *events.Message) {
+	if !isOwner(client, v.Info.Sender) && !(v.Info.IsGroup && isAdmin(client, v.Info.Chat, v.Info.Sender)) {
+		replyMessage(client, v, "👮 Only Admins or Owner!")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	bans := listGlobalBans(botID)
+	if len(bans) == 0 {
+		replyMessage(client, v, "📭 Global ban list is empty.")
+		return
+	}
+	out := fmt.Sprintf("╔════════════════╗\n║ 🚫 GLOBAL BANS (%d)\n╠════════════════\n", len(bans))
+	for i, b := range bans {
+		if i >= 50 {
+			out += fmt.Sprintf("║ ... and %d more\n", len(bans)-i)
+			break
+		}
+		from := ""
+		if b.BotID != "" && b.BotID != botID {
+			from = " | 🔗 " + b.BotID
+		}
+		out += fmt.Sprintf("║ %d. %s\n║    📌 %s | %s%s\n", i+1, b.User, b.Reason, b.At.Format("02 Jan 2006"), from)
+	}
+	out += "╚════════════════"
+	replyMessage(client, v, out)
+}
+
+// 🔗 .gban share on/off | .gban subscribe <bot> | .gban unsubscribe <bot>
+func handleGBanSharing(client *whatsmeow.Client, v *events.Message, sub string, args []string) {
+	if rdb == nil {
+		replyMessage(client, v, "⚠️ Redis not connected.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	arg := ""
+	if len(args) > 0 {
+		arg = strings.ToLower(strings.TrimPrefix(args[0], "+"))
+	}
+
+	switch sub {
+	case "share":
+		switch arg {
+		case "on":
+			rdb.SAdd(ctx, gbanSharedKey, botID)
+		case "off":
+			rdb.SRem(ctx, gbanSharedKey, botID)
+		case "":
+		default:
+			replyMessage(client, v, "⚠️ Usage: .gban share on | off")
+			return
+		}
+		invalidateGBanCache()
+
+	case "subscribe", "sub":
+		other := getCleanID(arg)
+		if other == "" || other == botID {
+			replyMessage(client, v, "⚠️ Usage: .gban subscribe <bot number>")
+			return
+		}
+		if ok, _ := rdb.SIsMember(ctx, gbanSharedKey, other).Result(); !ok {
+			replyMessage(client, v, "❌ Bot "+other+" does not share its ban list (.gban share on on that bot).")
+			return
+		}
+		rdb.SAdd(ctx, gbanSubscribeKey+botID, other)
+		invalidateGBanCache()
+
+	case "unsubscribe", "unsub":
+		other := getCleanID(arg)
+		if n, _ := rdb.SRem(ctx, gbanSubscribeKey+botID, other).Result(); n == 0 {
+			replyMessage(client, v, "ℹ️ Not subscribed to "+orDash(other)+".")
+			return
+		}
+		invalidateGBanCache()
+	}
+
+	shared := "❌ NO"
+	if ok, _ := rdb.SIsMember(ctx, gbanSharedKey, botID).Result(); ok {
+		shared = "✅ YES"
+	}
+	subs, _ := rdb.SMembers(ctx, gbanSubscribeKey+botID).Result()
+	sort.Strings(subs)
+	subsText := "None"
+	if len(subs) > 0 {
+		subsText = strings.Join(subs, ", ")
+	}
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🔗 BAN LIST SHARING
+╠════════════════╣
+║ 📤 Sharing my list: %s
+║ 📥 Subscribed to: %s
+╠════════════════╣
+║ .gban share on/off
+║ .gban subscribe <bot>
+║ .gban unsubscribe <bot>
+╚════════════════╝`, shared, subsText))
+}
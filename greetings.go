@@ -0,0 +1,420 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ==================== ویلکم / گڈ بائے ٹیمپلیٹس ====================
+// ہر ایونٹ (welcome, bye, kick, promote, demote) کا اپنا ٹیمپلیٹ، اپنا سوئچ اور آپشنل تصویر
+// پلیس ہولڈرز: {mention} {name} {group} {desc} {count} {admin} {rules}
+
+const greetingImagePrefix = "greeting:img:" // greeting:img:<chat>:<event> → کسٹم تصویر
+
+// GreetingDef ایک ایونٹ کی ڈیفینیشن
+type GreetingDef struct {
+	Event   string
+	Label   string
+	Emoji   string
+	Default string
+}
+
+var greetingDefs = []GreetingDef{
+	{"welcome", "Welcome", "👋", "╔════════════════╗\n║ 👋 WELCOME\n╠════════════════╣\n║ 👤 User: {mention}\n║ 🎉 Enjoy here!\n╚════════════════╝"},
+	{"bye", "Goodbye", "🚪", "╔════════════════╗\n║ 👋 GOODBYE\n╠════════════════╣\n║ 👤 User: {mention}\n║ 📉 Status: Left\n╚════════════════╝"},
+	{"kick", "Kicked", "👢", "╔════════════════╗\n║ 👢 KICKED\n╠════════════════╣\n║ 👤 User: {mention}\n║ 👮 By: {admin}\n╚════════════════╝"},
+	{"promote", "Promoted", "👑", "╔════════════════╗\n║ 👑 PROMOTED\n╠════════════════╣\n║ 👤 User: {mention}\n║ 🎉 New Admin!\n╚════════════════╝"},
+	{"demote", "Demoted", "📉", "╔════════════════╗\n║ 👤 DEMOTED\n╠════════════════╣\n║ 👤 User: {mention}\n║ 📉 Admin Removed\n╚════════════════╝"},
+}
+
+func greetingDefByEvent(event string) *GreetingDef {
+	for i := range greetingDefs {
+		if greetingDefs[i].Event == event {
+			return &greetingDefs[i]
+		}
+	}
+	return nil
+}
+
+// پرانا Welcome bool → ہر ایونٹ کا الگ سوئچ
+func migrateGreetings(s *GroupSettings) {
+	if s.Greetings != nil {
+		return
+	}
+	s.Greetings = make(map[string]*GreetingTemplate)
+	for _, def := range greetingDefs {
+		s.Greetings[def.Event] = &GreetingTemplate{Enabled: s.Welcome}
+	}
+}
+
+func ensureGreeting(s *GroupSettings, event string) *GreetingTemplate {
+	migrateGreetings(s)
+	g, ok := s.Greetings[event]
+	if !ok || g == nil {
+		g = &GreetingTemplate{}
+		s.Greetings[event] = g
+	}
+	return g
+}
+
+func greetingEnabled(s *GroupSettings, event string) bool {
+	migrateGreetings(s)
+	g := s.Greetings[event]
+	return g != nil && g.Enabled
+}
+
+func anyGreetingEnabled(s *GroupSettings) bool {
+	for _, def := range greetingDefs {
+		if greetingEnabled(s, def.Event) {
+			return true
+		}
+	}
+	return false
+}
+
+// greetingContext ایک گروپ کی معلومات (ایک ایونٹ کے کئی یوزرز کے لیے ایک بار)
+type greetingContext struct {
+	Group types.JID
+	Name  string
+	Desc  string
+	Count int
+	Rules string
+}
+
+func loadGreetingContext(client *whatsmeow.Client, s *GroupSettings, chat types.JID) *greetingContext {
+	gc := &greetingContext{Group: chat, Rules: groupRulesText(s)}
+	if info, err := client.GetGroupInfo(context.Background(), chat); err == nil {
+		gc.Name, gc.Desc, gc.Count = info.Name, info.Topic, len(info.Participants)
+	} else {
+		gc.Count = len(groupMembers(client, chat))
+	}
+	return gc
+}
+
+// 👤 ڈسپلے نام (کانٹیکٹ سٹور سے، ورنہ نمبر)
+func displayName(client *whatsmeow.Client, user types.JID) string {
+	if contact, err := client.Store.Contacts.GetContact(context.Background(), user); err == nil && contact.Found {
+		if contact.PushName != "" {
+			return contact.PushName
+		}
+		if contact.FullName != "" {
+			return contact.FullName
+		}
+	}
+	return getCleanID(user.User)
+}
+
+// 🔤 پلیس ہولڈرز بھریں، مینشنز کی لسٹ بھی واپس
+func renderGreeting(client *whatsmeow.Client, tmpl string, gc *greetingContext, user types.JID, admin *types.JID) (string, []string) {
+	mentions := []string{user.String()}
+	adminText := "-"
+	if admin != nil && !admin.IsEmpty() {
+		adminText = "@" + admin.User
+		mentions = append(mentions, admin.String())
+	}
+	rules := gc.Rules
+	if rules == "" {
+		rules = "No rules set."
+	}
+	r := strings.NewReplacer(
+		"{mention}", "@"+user.User,
+		"{name}", displayName(client, user),
+		"{group}", gc.Name,
+		"{desc}", gc.Desc,
+		"{count}", strconv.Itoa(gc.Count),
+		"{admin}", adminText,
+		"{rules}", rules,
+	)
+	return r.Replace(tmpl), mentions
+}
+
+// 🖼️ تصویر: یوزر کی DP، گروپ کی DP، یا اپلوڈ کی ہوئی
+func greetingImage(client *whatsmeow.Client, chat types.JID, event string, g *GreetingTemplate, user types.JID) []byte {
+	switch g.Image {
+	case "custom":
+		if rdb == nil {
+			return nil
+		}
+		data, _ := rdb.Get(ctx, greetingImagePrefix+chat.String()+":"+event).Bytes()
+		return data
+	case "user", "group":
+		target := user
+		if g.Image == "group" {
+			target = chat
+		}
+		pic, err := client.GetProfilePictureInfo(context.Background(), target, &whatsmeow.GetProfilePictureParams{})
+		if err != nil || pic == nil || pic.URL == "" {
+			return nil
+		}
+		resp, err := http.Get(pic.URL)
+		if err != nil {
+			return nil
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return data
+	}
+	return nil
+}
+
+// 📤 ایک یوزر کے لیے ٹیمپلیٹ بھیجیں (تصویر نہ ملے تو صرف ٹیکسٹ)
+func sendGreeting(client *whatsmeow.Client, s *GroupSettings, event string, gc *greetingContext, user types.JID, admin *types.JID) {
+	def := greetingDefByEvent(event)
+	g := ensureGreeting(s, event)
+	tmpl := g.Text
+	if tmpl == "" {
+		tmpl = def.Default
+	}
+	text, mentions := renderGreeting(client, tmpl, gc, user, admin)
+	bg := context.Background()
+
+	if img := greetingImage(client, gc.Group, event, g, user); len(img) > 0 {
+		if up, err := client.Upload(bg, img, whatsmeow.MediaImage); err == nil {
+			_, err = client.SendMessage(bg, gc.Group, &waProto.Message{
+				ImageMessage: &waProto.ImageMessage{
+					URL:           proto.String(up.URL),
+					DirectPath:    proto.String(up.DirectPath),
+					MediaKey:      up.MediaKey,
+					Mimetype:      proto.String("image/jpeg"),
+					Caption:       proto.String(text),
+					FileSHA256:    up.FileSHA256,
+					FileEncSHA256: up.FileEncSHA256,
+					FileLength:    proto.Uint64(uint64(len(img))),
+					ContextInfo:   &waProto.ContextInfo{MentionedJID: mentions},
+				},
+			})
+			if err == nil {
+				return
+			}
+		}
+	}
+
+	client.SendMessage(bg, gc.Group, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text:        proto.String(text),
+			ContextInfo: &waProto.ContextInfo{MentionedJID: mentions},
+		},
+	})
+}
+
+// ⚡ GroupInfo ایونٹ سے (handleGroupInfoChange)
+func sendGroupGreetings(client *whatsmeow.Client, s *GroupSettings, v *events.GroupInfo) {
+	var gc *greetingContext
+	send := func(event string, user types.JID, admin *types.JID) {
+		if !greetingEnabled(s, event) {
+			return
+		}
+		if gc == nil {
+			gc = loadGreetingContext(client, s, v.JID)
+		}
+		sendGreeting(client, s, event, gc, user, admin)
+		time.Sleep(500 * time.Millisecond) // چھوٹا سا وقفہ تاکہ واٹس ایپ بین نہ کرے
+	}
+
+	for _, left := range v.Leave {
+		if v.Sender == nil || v.Sender.User == left.User {
+			send("bye", left, nil)
+		} else {
+			send("kick", left, v.Sender)
+		}
+	}
+	for _, u := range v.Promote {
+		send("promote", u, v.Sender)
+	}
+	for _, u := range v.Demote {
+		send("demote", u, v.Sender)
+	}
+	for _, u := range v.Join {
+		send("welcome", u, nil)
+	}
+}
+
+// ==================== .welcome / .setwelcome / .setbye ====================
+func handleWelcomeCmd(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+	migrateGreetings(s)
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	// پرانا انداز: .welcome on/off = صرف جوائن والا
+	if sub == "on" || sub == "off" || sub == "enable" || sub == "disable" {
+		args = append([]string{"welcome"}, args...)
+		sub = "welcome"
+	}
+
+	switch {
+	case sub == "all" && len(args) > 1:
+		on := strings.ToLower(args[1]) == "on"
+		for _, def := range greetingDefs {
+			ensureGreeting(s, def.Event).Enabled = on
+		}
+		s.Welcome = on
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "✅ All greetings: "+onOffText(on))
+
+	case greetingDefByEvent(sub) != nil && len(args) > 1:
+		on := strings.ToLower(args[1]) == "on" || strings.ToLower(args[1]) == "enable"
+		ensureGreeting(s, sub).Enabled = on
+		s.Welcome = anyGreetingEnabled(s)
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("%s %s: %s", greetingDefByEvent(sub).Emoji, greetingDefByEvent(sub).Label, onOffText(on)))
+
+	case sub == "set" && len(args) > 2 && greetingDefByEvent(strings.ToLower(args[1])) != nil:
+		setGreetingText(client, v, s, botID, strings.ToLower(args[1]), greetingArgText(v, 3))
+
+	case sub == "reset" && len(args) > 1 && greetingDefByEvent(strings.ToLower(args[1])) != nil:
+		g := ensureGreeting(s, strings.ToLower(args[1]))
+		g.Text, g.Image = "", ""
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "♻️ Template reset to default.")
+
+	case sub == "image" && len(args) > 1 && greetingDefByEvent(strings.ToLower(args[1])) != nil:
+		setGreetingImage(client, v, s, botID, strings.ToLower(args[1]), args[2:])
+
+	case sub == "test":
+		event := "welcome"
+		if len(args) > 1 && greetingDefByEvent(strings.ToLower(args[1])) != nil {
+			event = strings.ToLower(args[1])
+		}
+		gc := loadGreetingContext(client, s, v.Info.Chat)
+		sender := v.Info.Sender
+		sendGreeting(client, s, event, gc, sender, &sender)
+
+	default:
+		out := "╔════════════════╗\n║ 👋 GREETINGS\n╠════════════════╣\n"
+		for _, def := range greetingDefs {
+			g := ensureGreeting(s, def.Event)
+			extra := ""
+			if g.Text != "" {
+				extra += " ✏️"
+			}
+			if g.Image != "" {
+				extra += " 🖼️" + g.Image
+			}
+			out += fmt.Sprintf("║ %s %s: %s%s\n", def.Emoji, def.Label, onOffText(g.Enabled), extra)
+		}
+		out += `╠════════════════╣
+║ .welcome <event> on/off
+║ .welcome all on/off
+║ .setwelcome <text>
+║ .setbye <text>
+║ .welcome set <event> <text>
+║ .welcome image <event> user/group/off
+║   (reply to an image = custom)
+║ .welcome reset <event>
+║ .welcome test [event]
+╠════════════════╣
+║ Events: welcome bye kick promote demote
+║ {mention} {name} {group} {desc}
+║ {count} {admin} {rules}
+╚════════════════╝`
+		replyMessage(client, v, out)
+	}
+}
+
+// ملٹی لائن ٹیمپلیٹ: کمانڈ کے پہلے n الفاظ کے بعد کا اصل ٹیکسٹ (نئی لائنیں برقرار)
+func greetingArgText(v *events.Message, skipWords int) string {
+	text := strings.TrimSpace(getText(v.Message))
+	for i := 0; i < skipWords; i++ {
+		text = strings.TrimLeft(text, " \t")
+		if idx := strings.IndexAny(text, " \t\n"); idx >= 0 {
+			text = text[idx:]
+		} else {
+			return ""
+		}
+	}
+	return strings.TrimSpace(text)
+}
+
+func setGreetingText(client *whatsmeow.Client, v *events.Message, s *GroupSettings, botID, event, text string) {
+	if text == "" {
+		replyMessage(client, v, "⚠️ Please provide the template text. Placeholders: {mention} {name} {group} {desc} {count} {admin} {rules}")
+		return
+	}
+	g := ensureGreeting(s, event)
+	g.Text = text
+	g.Enabled = true
+	s.Welcome = true
+	saveGroupSettings(botID, s)
+	replyMessage(client, v, fmt.Sprintf("✅ %s template saved. Try .welcome test %s", greetingDefByEvent(event).Label, event))
+}
+
+func setGreetingImage(client *whatsmeow.Client, v *events.Message, s *GroupSettings, botID, event string, args []string) {
+	g := ensureGreeting(s, event)
+	mode := ""
+	if len(args) > 0 {
+		mode = strings.ToLower(args[0])
+	}
+
+	quoted := v.Message.GetExtendedTextMessage().GetContextInfo().GetQuotedMessage()
+	if img := quoted.GetImageMessage(); img != nil {
+		if rdb == nil {
+			replyMessage(client, v, "⚠️ Redis not connected.")
+			return
+		}
+		data, err := client.Download(context.Background(), img)
+		if err != nil {
+			replyMessage(client, v, "❌ Could not download the image.")
+			return
+		}
+		rdb.Set(ctx, greetingImagePrefix+v.Info.Chat.String()+":"+event, data, 0)
+		mode = "custom"
+	}
+
+	switch mode {
+	case "custom", "user", "group":
+		g.Image = mode
+	case "off", "none":
+		g.Image = ""
+		if rdb != nil {
+			rdb.Del(ctx, greetingImagePrefix+v.Info.Chat.String()+":"+event)
+		}
+	default:
+		replyMessage(client, v, "⚠️ Usage: .welcome image <event> user/group/off (or reply to an image)")
+		return
+	}
+	saveGroupSettings(botID, s)
+	replyMessage(client, v, fmt.Sprintf("🖼️ %s image: %s", greetingDefByEvent(event).Label, orDash(g.Image)))
+}
+
+// .setwelcome / .setbye / .setkick / .setpromote / .setdemote <text>
+func handleSetGreeting(client *whatsmeow.Client, v *events.Message, event string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+	if greetingDefByEvent(event) == nil {
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+	setGreetingText(client, v, s, botID, event, greetingArgText(v, 1))
+}
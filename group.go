@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"go.mau.fi/whatsmeow"
@@ -98,7 +100,7 @@ func handleTagAll(client *whatsmeow.Client, v *events.Message, args []string) {
 		return
 	}
 
-	info, _ := client.GetGroupInfo(context.Background(), v.Info.Chat)
+	members := groupMembers(client, v.Info.Chat)
 	mentions := []string{}
 	out := "╔════════════════╗\n"
 	out += "║ 📣 TAG ALL\n"
@@ -108,12 +110,12 @@ func handleTagAll(client *whatsmeow.Client, v *events.Message, args []string) {
 		out += "║ 💬 " + strings.Join(args, " ") + "\n"
 	}
 
-	for _, p := range info.Participants {
+	for _, p := range members {
 		mentions = append(mentions, p.JID.String())
 		out += "║ @" + p.JID.User + "\n"
 	}
 
-	out += fmt.Sprintf("║ 👥 Total: %d\n", len(info.Participants))
+	out += fmt.Sprintf("║ 👥 Total: %d\n", len(members))
 	out += "╚════════════════"
 
 	client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
@@ -151,7 +153,7 @@ func handleHideTag(client *whatsmeow.Client, v *events.Message, args []string) {
 		return
 	}
 
-	info, _ := client.GetGroupInfo(context.Background(), v.Info.Chat)
+	members := groupMembers(client, v.Info.Chat)
 	mentions := []string{}
 	text := strings.Join(args, " ")
 
@@ -159,7 +161,7 @@ func handleHideTag(client *whatsmeow.Client, v *events.Message, args []string) {
 		text = "🔔 Hidden Tag"
 	}
 
-	for _, p := range info.Participants {
+	for _, p := range members {
 		mentions = append(mentions, p.JID.String())
 	}
 
@@ -240,17 +242,30 @@ func handleGroup(client *whatsmeow.Client, v *events.Message, args []string) {
 		replyMessage(client, v, msg)
 
 	case "link":
-		code, _ := client.GetGroupInviteLink(context.Background(), v.Info.Chat, false)
+		// ہمیشہ لائیو لنک (ٹریکنگ میں بھی ریکارڈ)
+		g, err := liveInviteLink(client, v.Info.Chat)
+		if err != nil || g == nil {
+			replyMessage(client, v, "⚠️ Failed to get the group link (Give me Admin Rights)")
+			return
+		}
 		msg := fmt.Sprintf(`╔════════════════╗
 ║ 🔗 LINK
 ╠════════════════
 ║ Group Link 🖇️ 
 ║ %s
-╚════════════════`, code)
+║ Gen #%d | .invite stats
+╚════════════════`, g.Link, g.Gen)
 		replyMessage(client, v, msg)
 
 	case "revoke":
-		client.GetGroupInviteLink(context.Background(), v.Info.Chat, true)
+		if _, err := rotateInviteLink(client, getCleanID(client.Store.ID.User), v.Info.Chat, "manual", getCleanID(v.Info.Sender.User)); err != nil {
+			if errors.Is(err, errInviteRotating) {
+				replyMessage(client, v, "⏳ The link is being reset right now, use .group link in a moment.")
+			} else {
+				replyMessage(client, v, "❌ Could not revoke the link: "+err.Error())
+			}
+			return
+		}
 		msg := `╔════════════════╗
 ║ 🔄 REVOKED
 ╠════════════════
@@ -305,7 +320,9 @@ func handleDelete(client *whatsmeow.Client, v *events.Message) {
 		return
 	}
 
-	client.RevokeMessage(context.Background(), v.Info.Chat, *ctx.StanzaID)
+	if _, err := client.RevokeMessage(context.Background(), v.Info.Chat, *ctx.StanzaID); err == nil {
+		logManualModAction(client, v, "delete", getCleanID(ctx.GetParticipant()), messageExcerpt(ctx.QuotedMessage), *ctx.StanzaID)
+	}
 
 	msg := `╔════════════════╗
 ║ 🗑️ DELETED
@@ -407,7 +424,13 @@ func groupAction(client *whatsmeow.Client, v *events.Message, args []string, act
 		actionEmoji = "⬇️"
 	}
 
-	client.UpdateGroupParticipants(context.Background(), v.Info.Chat, []types.JID{targetJID}, participantChange)
+	if _, err := client.UpdateGroupParticipants(context.Background(), v.Info.Chat, []types.JID{targetJID}, participantChange); err == nil {
+		logAction := action
+		if action == "remove" {
+			logAction = "kick"
+		}
+		logManualModAction(client, v, logAction, getCleanID(targetJID.User), "", "")
+	}
 
 	msg := fmt.Sprintf(`╔════════════════╗
 ║ %s %s
@@ -426,4 +449,46 @@ func groupAction(client *whatsmeow.Client, v *events.Message, args []string, act
 			},
 		},
 	})
-}
\ No newline at end of file
+}
+// 🎯 ٹارگٹ یوزر نکالیں: مینشن > ریپلائی > نمبر (باقی args واپس)
+func extractTargetJID(v *events.Message, args []string) (types.JID, []string) {
+	var rest []string
+	for _, a := range args {
+		if !strings.HasPrefix(a, "@") {
+			rest = append(rest, a)
+		}
+	}
+
+	if ctxInfo := v.Message.GetExtendedTextMessage().GetContextInfo(); ctxInfo != nil {
+		if len(ctxInfo.MentionedJID) > 0 {
+			if jid, err := types.ParseJID(ctxInfo.MentionedJID[0]); err == nil {
+				return jid, rest
+			}
+		}
+		if ctxInfo.Participant != nil {
+			if jid, err := types.ParseJID(*ctxInfo.Participant); err == nil {
+				return jid, rest
+			}
+		}
+	}
+
+	if len(rest) > 0 {
+		num := strings.ReplaceAll(rest[0], "+", "")
+		if _, err := strconv.ParseUint(getCleanID(num), 10, 64); err == nil {
+			if jid, ok := parseJID(num); ok {
+				return jid, rest[1:]
+			}
+		}
+	}
+	return types.EmptyJID, rest
+}
+
+// 📤 گروپ/چیٹ میں سادہ ٹیکسٹ (بیک گراؤنڈ لوپس کے لیے، جہاں ریپلائی کرنے کو میسج نہیں)
+func sendPlainText(client *whatsmeow.Client, chat types.JID, text string) {
+	_, err := client.SendMessage(context.Background(), chat, &waProto.Message{
+		Conversation: proto.String(text),
+	})
+	if err != nil {
+		fmt.Printf("⚠️ Failed to send message to %s: %v\n", chat, err)
+	}
+}
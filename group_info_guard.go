@@ -0,0 +1,381 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ==================== گروپ انفو گارڈ ====================
+// گروپ کا نام، ڈسکرپشن اور آئیکن سنیپ شاٹ ہوتا ہے
+// غیر ایڈمن تبدیلی کرے تو واپس پلٹ دیا جاتا ہے، ہر تبدیلی ہسٹری میں محفوظ (.groupinfo history)
+
+const (
+	infoSnapPrefix    = "groupinfo:snap:"
+	infoPicPrefix     = "groupinfo:pic:"
+	infoHistoryPrefix = "groupinfo:history:"
+	infoLockPrefix    = "groupinfo:lock:"
+	infoHistoryMax    = 50
+)
+
+// GroupInfoSnapshot آخری منظور شدہ حالت
+type GroupInfoSnapshot struct {
+	Name      string    `json:"name"`
+	Topic     string    `json:"topic"`
+	PictureID string    `json:"picture_id"`
+	TakenAt   time.Time `json:"taken_at"`
+}
+
+// GroupInfoChange ہسٹری کی ایک لائن
+type GroupInfoChange struct {
+	Field    string    `json:"field"` // name / topic / picture
+	Old      string    `json:"old"`
+	New      string    `json:"new"`
+	By       string    `json:"by"`
+	Reverted bool      `json:"reverted"`
+	At       time.Time `json:"at"`
+}
+
+func getInfoSnapshot(chatID string) *GroupInfoSnapshot {
+	if rdb == nil {
+		return nil
+	}
+	val, err := rdb.Get(ctx, infoSnapPrefix+chatID).Result()
+	if err != nil {
+		return nil
+	}
+	var snap GroupInfoSnapshot
+	if json.Unmarshal([]byte(val), &snap) != nil {
+		return nil
+	}
+	return &snap
+}
+
+func saveInfoSnapshot(chatID string, snap *GroupInfoSnapshot) {
+	if rdb == nil {
+		return
+	}
+	payload, _ := json.Marshal(snap)
+	rdb.Set(ctx, infoSnapPrefix+chatID, payload, 0)
+}
+
+func recordInfoChange(chatID string, c GroupInfoChange) {
+	if rdb == nil {
+		return
+	}
+	payload, _ := json.Marshal(c)
+	key := infoHistoryPrefix + chatID
+	rdb.LPush(ctx, key, payload)
+	rdb.LTrim(ctx, key, 0, infoHistoryMax-1)
+}
+
+// 🖼️ گروپ آئیکن ڈاؤنلوڈ (ریورٹ کے لیے بائٹس)
+// آئیکن نہیں = ("", nil, nil)؛ ایرر ہو تو پتا نہیں کہ آئیکن ہے یا نہیں
+func fetchGroupPicture(client *whatsmeow.Client, chat types.JID) (string, []byte, error) {
+	pic, err := client.GetProfilePictureInfo(context.Background(), chat, &whatsmeow.GetProfilePictureParams{Preview: false})
+	if errors.Is(err, whatsmeow.ErrProfilePictureNotSet) {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	if pic == nil || pic.URL == "" {
+		return "", nil, fmt.Errorf("no picture info returned")
+	}
+	resp, err := http.Get(pic.URL)
+	if err != nil {
+		return pic.ID, nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return pic.ID, nil, fmt.Errorf("picture download: %s", resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return pic.ID, nil, err
+	}
+	return pic.ID, data, nil
+}
+
+// 📸 موجودہ حالت کا سنیپ شاٹ
+func takeInfoSnapshot(client *whatsmeow.Client, chat types.JID) (*GroupInfoSnapshot, error) {
+	info, err := client.GetGroupInfo(context.Background(), chat)
+	if err != nil {
+		return nil, err
+	}
+	snap := &GroupInfoSnapshot{Name: info.Name, Topic: info.Topic, TakenAt: time.Now()}
+	picID, picData, picErr := fetchGroupPicture(client, chat)
+	snap.PictureID = picID
+	if picErr != nil && picID == "" {
+		// آئیکن کا پتا نہیں چلا: پچھلا ریکارڈ برقرار
+		if old := getInfoSnapshot(chat.String()); old != nil {
+			snap.PictureID = old.PictureID
+		}
+	}
+	saveInfoSnapshot(chat.String(), snap)
+	if rdb != nil {
+		switch {
+		case picData != nil:
+			rdb.Set(ctx, infoPicPrefix+chat.String(), picData, 0)
+		case picID != "":
+			// آئیکن ہے مگر بائٹس نہیں ملیں: پرانی تصویر نہ رکھیں، ریورٹ اسکپ ہو گا
+			rdb.Del(ctx, infoPicPrefix+chat.String())
+		case picErr == nil:
+			rdb.Del(ctx, infoPicPrefix+chat.String()) // واقعی کوئی آئیکن نہیں
+		}
+	}
+	return snap, nil
+}
+
+// کون تبدیلی کر سکتا ہے: بوٹ خود یا ایڈمن
+func infoChangeAuthorized(client *whatsmeow.Client, chat, actor types.JID) bool {
+	if actor.IsEmpty() {
+		return true // سرور کی طرف سے، نامعلوم ایکٹر
+	}
+	if client.Store.ID != nil && actor.User == client.Store.ID.User {
+		return true
+	}
+	if !client.Store.LID.IsEmpty() && actor.User == client.Store.LID.User {
+		return true
+	}
+	return isAdmin(client, chat, actor)
+}
+
+// ایک ہی بوٹ ریورٹ کرے
+func acquireInfoLock(chatID, field string, ts time.Time) bool {
+	if rdb == nil {
+		return true
+	}
+	ok, err := rdb.SetNX(ctx, fmt.Sprintf("%s%s:%s:%d", infoLockPrefix, chatID, field, ts.Unix()), 1, time.Minute).Result()
+	return err == nil && ok
+}
+
+// 📝 نام / ڈسکرپشن (GroupInfo ایونٹ سے)
+func guardGroupInfoText(client *whatsmeow.Client, v *events.GroupInfo) {
+	if v.Name == nil && v.Topic == nil {
+		return
+	}
+	chatID := v.JID.String()
+	snap := getInfoSnapshot(chatID)
+	if snap == nil {
+		takeInfoSnapshot(client, v.JID)
+		return
+	}
+
+	var actor types.JID
+	if v.Sender != nil {
+		actor = *v.Sender
+	}
+	authorized := infoChangeAuthorized(client, v.JID, actor)
+	if !acquireInfoLock(chatID, "text", v.Timestamp) {
+		return
+	}
+	bg := context.Background()
+	by := getCleanID(actor.User)
+
+	if v.Name != nil && v.Name.Name != snap.Name {
+		change := GroupInfoChange{Field: "name", Old: snap.Name, New: v.Name.Name, By: by, At: time.Now()}
+		if authorized {
+			snap.Name = v.Name.Name
+		} else if botIsGroupAdmin(client, v.JID) {
+			if err := client.SetGroupName(bg, v.JID, snap.Name); err == nil {
+				change.Reverted = true
+			}
+		}
+		recordInfoChange(chatID, change)
+		if change.Reverted {
+			sendPlainText(client, v.JID, fmt.Sprintf("🛡️ Group name change by +%s reverted.", by))
+		}
+	}
+
+	if v.Topic != nil && v.Topic.Topic != snap.Topic {
+		change := GroupInfoChange{Field: "topic", Old: snap.Topic, New: v.Topic.Topic, By: by, At: time.Now()}
+		if authorized {
+			snap.Topic = v.Topic.Topic
+		} else if botIsGroupAdmin(client, v.JID) {
+			if err := client.SetGroupTopic(bg, v.JID, v.Topic.TopicID, "", snap.Topic); err == nil {
+				change.Reverted = true
+			}
+		}
+		recordInfoChange(chatID, change)
+		if change.Reverted {
+			sendPlainText(client, v.JID, fmt.Sprintf("🛡️ Group description change by +%s reverted.", by))
+		}
+	}
+
+	saveInfoSnapshot(chatID, snap)
+}
+
+// 🖼️ آئیکن (events.Picture سے)
+func guardGroupPicture(client *whatsmeow.Client, v *events.Picture) {
+	chatID := v.JID.String()
+	botID := getCleanID(client.Store.ID.User)
+	if !getGroupSettings(botID, chatID).InfoGuard {
+		return
+	}
+	// بوٹ کا اپنا ریورٹ بھی Picture ایونٹ بن کر آتا ہے
+	if isDeploymentBot(v.Author) {
+		return
+	}
+	snap := getInfoSnapshot(chatID)
+	if snap == nil {
+		takeInfoSnapshot(client, v.JID)
+		return
+	}
+	newID := v.PictureID
+	if v.Remove {
+		newID = "removed"
+	}
+	if newID == snap.PictureID || !acquireInfoLock(chatID, "picture", v.Timestamp) {
+		return
+	}
+
+	by := getCleanID(v.Author.User)
+	change := GroupInfoChange{Field: "picture", Old: snap.PictureID, New: newID, By: by, At: time.Now()}
+
+	if infoChangeAuthorized(client, v.JID, v.Author) {
+		// منظور شدہ تبدیلی: نیا آئیکن سنیپ شاٹ میں
+		recordInfoChange(chatID, change)
+		takeInfoSnapshot(client, v.JID)
+		return
+	}
+
+	var old []byte
+	if rdb != nil {
+		old, _ = rdb.Get(ctx, infoPicPrefix+chatID).Bytes()
+	}
+	hadPicture := snap.PictureID != "" && snap.PictureID != "removed"
+	if hadPicture && old == nil {
+		// پرانا آئیکن تھا مگر اس کی کاپی نہیں، ہٹانے کے بجائے چھوڑ دیں
+		fmt.Printf("⚠️ [INFOGUARD] No stored icon for %s, skipping revert\n", chatID)
+	} else if botIsGroupAdmin(client, v.JID) {
+		// پہلے آئیکن نہیں تھا تو ہٹا دیں، ورنہ پرانا واپس
+		if newID, err := client.SetGroupPhoto(context.Background(), v.JID, old); err == nil {
+			change.Reverted = true
+			snap.PictureID = newID
+			if old == nil {
+				snap.PictureID = "removed"
+			}
+			saveInfoSnapshot(chatID, snap)
+		} else {
+			fmt.Printf("⚠️ [INFOGUARD] Picture revert failed in %s: %v\n", chatID, err)
+		}
+	}
+	recordInfoChange(chatID, change)
+	if change.Reverted {
+		sendPlainText(client, v.JID, fmt.Sprintf("🛡️ Group icon change by +%s reverted.", by))
+	}
+}
+
+// ==================== .groupinfo ====================
+func handleGroupInfoCmd(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	chatID := v.Info.Chat.String()
+	s := getGroupSettings(botID, chatID)
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	switch sub {
+	case "guard":
+		on := len(args) > 1 && strings.ToLower(args[1]) == "on"
+		s.InfoGuard = on
+		saveGroupSettings(botID, s)
+		if !on {
+			replyMessage(client, v, "❌ Group info guard OFF.")
+			return
+		}
+		if _, err := takeInfoSnapshot(client, v.Info.Chat); err != nil {
+			replyMessage(client, v, "⚠️ Guard ON, but snapshot failed: "+err.Error())
+			return
+		}
+		replyMessage(client, v, "✅ Group info guard ON. Name, description and icon snapshot saved.")
+
+	case "snapshot", "snap":
+		if _, err := takeInfoSnapshot(client, v.Info.Chat); err != nil {
+			replyMessage(client, v, "❌ Snapshot failed: "+err.Error())
+			return
+		}
+		replyMessage(client, v, "📸 Snapshot updated to the current name, description and icon.")
+
+	case "history":
+		if rdb == nil {
+			replyMessage(client, v, "⚠️ Redis not connected.")
+			return
+		}
+		vals, _ := rdb.LRange(ctx, infoHistoryPrefix+chatID, 0, 14).Result()
+		if len(vals) == 0 {
+			replyMessage(client, v, "📭 No group info changes recorded yet.")
+			return
+		}
+		icons := map[string]string{"name": "🏷️", "topic": "📝", "picture": "🖼️"}
+		out := "╔════════════════╗\n║ 📜 GROUP INFO HISTORY\n╠════════════════\n"
+		for _, val := range vals {
+			var c GroupInfoChange
+			if json.Unmarshal([]byte(val), &c) != nil {
+				continue
+			}
+			status := "✅ kept"
+			if c.Reverted {
+				status = "↩️ reverted"
+			}
+			out += fmt.Sprintf("║ %s %s | %s\n║    👤 %s | %s\n", icons[c.Field], strings.ToUpper(c.Field), c.At.Format("02 Jan 15:04"), c.By, status)
+			if c.Field != "picture" {
+				out += fmt.Sprintf("║    %s → %s\n", shortText(c.Old, 40), shortText(c.New, 40))
+			}
+		}
+		out += "╚════════════════"
+		replyMessage(client, v, out)
+
+	default:
+		status := "🔴 OFF"
+		if s.InfoGuard {
+			status = "🟢 ON"
+		}
+		snapTime := "-"
+		if snap := getInfoSnapshot(chatID); snap != nil {
+			snapTime = snap.TakenAt.Format("02 Jan 15:04")
+		}
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🏷️ GROUP INFO GUARD
+╠════════════════╣
+║ Status: %s
+║ Snapshot: %s
+╠════════════════╣
+║ .groupinfo guard on/off
+║ .groupinfo snapshot
+║ .groupinfo history
+╚════════════════╝`, status, snapTime))
+	}
+}
+
+func shortText(s string, n int) string {
+	if s == "" {
+		return "(empty)"
+	}
+	r := []rune(strings.ReplaceAll(s, "\n", " "))
+	if len(r) > n {
+		return string(r[:n]) + "…"
+	}
+	return string(r)
+}
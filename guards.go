@@ -0,0 +1,48 @@
+package main
+
+import (
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ==================== گروپ گارڈز ====================
+// ہر گروپ میسج (کمانڈ ہو یا نہ ہو، ٹیکسٹ ہو یا میڈیا) پہلے ان چیکس سے گزرتا ہے
+// اینٹی بگ اسکینر DMs پر بھی چلتا ہے
+// true = میسج روک دیا گیا، آگے پروسیس نہ کریں
+
+func runGroupGuards(client *whatsmeow.Client, v *events.Message) bool {
+	if v.Info.IsFromMe {
+		return false
+	}
+
+	// 🧨 کریش ٹیکسٹ / خراب میسج
+	if enforceCrashGuard(client, v) {
+		return true
+	}
+
+	if !v.Info.IsGroup {
+		return false
+	}
+
+	// 🚫 گلوبل بین
+	if enforceGlobalBanOnMessage(client, v) {
+		return true
+	}
+
+	// 🚫 بلاک لسٹ والا میڈیا
+	if enforceBannedMedia(client, v) {
+		return true
+	}
+
+	// 🧹 کراس گروپ سپیم
+	if checkCrossGroupSpam(client, v) {
+		return true
+	}
+
+	// 🐢 سلو موڈ (کمانڈز سے پہلے)
+	if enforceSlowMode(client, v) {
+		return true
+	}
+
+	return false
+}
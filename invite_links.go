@@ -0,0 +1,359 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ==================== انوائٹ لنک ٹریکنگ / روٹیشن ====================
+// ہر نیا لنک ایک "جنریشن"، لنک سے آنے والے جوائنز اسی جنریشن کے کھاتے میں
+// شیڈول یا N جوائنز کے بعد خودکار نیا لنک، ریڈ لاک ڈاؤن پر لیک شدہ لنک فوراً منسوخ
+
+const (
+	invitePrefix        = "invite:"
+	inviteHistoryMax    = 20
+	inviteRotatorTick   = 5 * time.Minute
+	inviteLinkURLPrefix = "https://chat.whatsapp.com/"
+)
+
+// کوئی دوسرا بوٹ / کال ابھی لنک بدل رہی ہے
+var errInviteRotating = errors.New("link rotation already in progress")
+
+// InviteGen ایک لنک جنریشن
+type InviteGen struct {
+	Gen       int       `json:"gen"`
+	Link      string    `json:"link"`
+	CreatedAt time.Time `json:"created_at"`
+	Reason    string    `json:"reason"` // manual / schedule / joins / antiraid / external / first
+	By        string    `json:"by"`
+	Joins     int       `json:"joins"`
+	EndedAt   time.Time `json:"ended_at,omitempty"`
+	EndReason string    `json:"end_reason,omitempty"`
+}
+
+func inviteKey(kind, chatID string) string {
+	return invitePrefix + kind + ":" + chatID
+}
+
+func normalizeInviteLink(link string) string {
+	link = strings.TrimSpace(link)
+	if link != "" && !strings.HasPrefix(link, "http") {
+		link = inviteLinkURLPrefix + link
+	}
+	return link
+}
+
+func getCurrentInvite(chatID string) *InviteGen {
+	if rdb == nil {
+		return nil
+	}
+	val, err := rdb.Get(ctx, inviteKey("current", chatID)).Result()
+	if err != nil {
+		return nil
+	}
+	var g InviteGen
+	if json.Unmarshal([]byte(val), &g) != nil {
+		return nil
+	}
+	// جوائنز الگ کاؤنٹر میں (کئی بوٹس ایک ساتھ بڑھا سکیں)
+	g.Joins, _ = rdb.HGet(ctx, inviteKey("joins", chatID), strconv.Itoa(g.Gen)).Int()
+	return &g
+}
+
+func saveCurrentInvite(chatID string, g *InviteGen) {
+	if payload, err := json.Marshal(g); err == nil {
+		rdb.Set(ctx, inviteKey("current", chatID), payload, 0)
+	}
+}
+
+// 📝 لنک ریکارڈ کریں، مختلف ہو تو نئی جنریشن (پرانی ہسٹری میں)
+func recordInviteLink(chatID, link, reason, by string) *InviteGen {
+	link = normalizeInviteLink(link)
+	if rdb == nil || link == "" || link == inviteLinkURLPrefix {
+		return nil
+	}
+	cur := getCurrentInvite(chatID)
+	if cur != nil && cur.Link == link {
+		return cur
+	}
+	gen := &InviteGen{Gen: 1, Link: link, CreatedAt: time.Now(), Reason: reason, By: by}
+	if cur != nil {
+		gen.Gen = cur.Gen + 1
+		cur.EndedAt, cur.EndReason = time.Now(), reason
+		if payload, err := json.Marshal(cur); err == nil {
+			rdb.LPush(ctx, inviteKey("history", chatID), payload)
+			rdb.LTrim(ctx, inviteKey("history", chatID), 0, inviteHistoryMax-1)
+		}
+	}
+	saveCurrentInvite(chatID, gen)
+	return gen
+}
+
+// 🔗 موجودہ لائیو لنک (واٹس ایپ سے، باہر سے بدلا ہو تو نئی جنریشن)
+func liveInviteLink(client *whatsmeow.Client, chat types.JID) (*InviteGen, error) {
+	link, err := client.GetGroupInviteLink(context.Background(), chat, false)
+	if err != nil {
+		return nil, err
+	}
+	reason := "external"
+	if getCurrentInvite(chat.String()) == nil {
+		reason = "first"
+	}
+	return recordInviteLink(chat.String(), link, reason, "-"), nil
+}
+
+// 🔄 نیا لنک بنائیں (ایک وقت میں ایک بوٹ، مصروف ہو تو errInviteRotating)
+func rotateInviteLink(client *whatsmeow.Client, botID string, chat types.JID, reason, by string) (*InviteGen, error) {
+	chatID := chat.String()
+	if rdb != nil {
+		lockKey := inviteKey("lock", chatID)
+		if ok, _ := rdb.SetNX(ctx, lockKey, botID, 30*time.Second).Result(); !ok {
+			return nil, errInviteRotating
+		}
+		defer rdb.Del(ctx, lockKey)
+	}
+	link, err := client.GetGroupInviteLink(context.Background(), chat, true)
+	if err != nil {
+		return nil, err
+	}
+	gen := recordInviteLink(chatID, link, reason, by)
+	logModAction(ModLogEntry{
+		BotID: botID, GroupID: chatID, Actor: by, Target: "invite link",
+		Action: "linkrotate", Rule: "invite", Reason: reason, Auto: by == "bot",
+	})
+	return gen, nil
+}
+
+// ⏳ لاک خالی ہونے کا انتظار کر کے روٹیٹ (لیک شدہ لنک ہر حال میں منسوخ ہو)
+func rotateInviteLinkWait(client *whatsmeow.Client, botID string, chat types.JID, reason, by string, wait time.Duration) (*InviteGen, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		gen, err := rotateInviteLink(client, botID, chat, reason, by)
+		if !errors.Is(err, errInviteRotating) || time.Now().After(deadline) {
+			return gen, err
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// 👥 GroupInfo سے: لنک والے جوائنز گنیں، N پر روٹیٹ؛ باہر سے بدلا لنک ریکارڈ
+func trackInviteEvents(client *whatsmeow.Client, botID string, s *GroupSettings, v *events.GroupInfo) {
+	if rdb == nil {
+		return
+	}
+	chatID := v.JID.String()
+	if v.NewInviteLink != nil {
+		recordInviteLink(chatID, *v.NewInviteLink, "external", "-")
+	}
+	if len(v.Join) == 0 {
+		return
+	}
+
+	// کئی بوٹس ایک ہی ایونٹ دیکھتے ہیں، ایک بار گنیں
+	dedup := fmt.Sprintf("%sseen:%s:%s:%d", invitePrefix, chatID, v.Join[0].User, v.Timestamp.Unix())
+	if ok, _ := rdb.SetNX(ctx, dedup, 1, 10*time.Minute).Result(); !ok {
+		return
+	}
+
+	reason := v.JoinReason
+	if reason == "" {
+		reason = "added"
+	}
+	rdb.HIncrBy(ctx, inviteKey("reasons", chatID), reason, int64(len(v.Join)))
+	if v.JoinReason != "invite" {
+		return
+	}
+
+	cur := getCurrentInvite(chatID)
+	if cur == nil {
+		if cur, _ = liveInviteLink(client, v.JID); cur == nil {
+			return
+		}
+	}
+	joins, _ := rdb.HIncrBy(ctx, inviteKey("joins", chatID), strconv.Itoa(cur.Gen), int64(len(v.Join))).Result()
+
+	rule := s.InviteRotation
+	if rule.AfterJoins > 0 && int(joins) >= rule.AfterJoins && botIsGroupAdmin(client, v.JID) {
+		rotateInviteLink(client, botID, v.JID, "joins", "bot")
+	}
+}
+
+// ⏰ شیڈول روٹیشن (ہر بوٹ کے لیے، ConnectNewSession سے)
+func StartInviteRotator(client *whatsmeow.Client) {
+	go func() {
+		for {
+			time.Sleep(inviteRotatorTick)
+			if !isClientActive(client) {
+				return
+			}
+			if rdb == nil || !client.IsConnected() {
+				continue
+			}
+			botID := getCleanID(client.Store.ID.User)
+			for _, s := range listBotGroupSettings(botID) {
+				rule := s.InviteRotation
+				if rule.EveryHours <= 0 {
+					continue
+				}
+				chat, err := types.ParseJID(s.ChatID)
+				if err != nil || !botIsGroupAdmin(client, chat) {
+					continue
+				}
+				cur := getCurrentInvite(s.ChatID)
+				if cur == nil {
+					liveInviteLink(client, chat) // پہلی بار: صرف ریکارڈ، گنتی یہیں سے
+					continue
+				}
+				if time.Since(cur.CreatedAt) >= time.Duration(rule.EveryHours)*time.Hour {
+					if _, err := rotateInviteLink(client, botID, chat, "schedule", "bot"); err != nil {
+						fmt.Printf("⚠️ [INVITE] Rotation failed for %s: %v\n", s.ChatID, err)
+					}
+				}
+			}
+		}
+	}()
+}
+
+func inviteRotationText(r InviteRotationRule) string {
+	var parts []string
+	if r.EveryHours > 0 {
+		parts = append(parts, fmt.Sprintf("every %dh", r.EveryHours))
+	}
+	if r.AfterJoins > 0 {
+		parts = append(parts, fmt.Sprintf("after %d joins", r.AfterJoins))
+	}
+	if len(parts) == 0 {
+		return onOffText(false)
+	}
+	return "🟢 " + strings.Join(parts, ", ")
+}
+
+// ==================== .invite ====================
+func handleInvite(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "⚠️ Redis not connected.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	chatID := v.Info.Chat.String()
+	s := getGroupSettings(botID, chatID)
+
+	sub, val := "", ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+	if len(args) > 1 {
+		val = strings.ToLower(args[1])
+	}
+
+	switch sub {
+	case "rotate", "revoke":
+		if !botIsGroupAdmin(client, v.Info.Chat) {
+			replyMessage(client, v, "❌ I need to be an admin to reset the link.")
+			return
+		}
+		gen, err := rotateInviteLink(client, botID, v.Info.Chat, "manual", getCleanID(v.Info.Sender.User))
+		if errors.Is(err, errInviteRotating) {
+			replyMessage(client, v, "⏳ The link is being reset right now, check .invite in a moment.")
+			return
+		}
+		if err != nil || gen == nil {
+			replyMessage(client, v, "❌ Could not reset the link.")
+			return
+		}
+		replyMessage(client, v, fmt.Sprintf("🔄 New invite link (gen #%d):\n%s", gen.Gen, gen.Link))
+
+	case "every":
+		if val == "off" || val == "0" {
+			s.InviteRotation.EveryHours = 0
+		} else {
+			var d time.Duration
+			var err error
+			if h, ok := strings.CutSuffix(val, "h"); ok {
+				var n int
+				n, err = strconv.Atoi(h)
+				d = time.Duration(n) * time.Hour
+			} else {
+				d, err = parseActivityPeriod(val) // "7d" / "2w"
+			}
+			if err != nil || d < time.Hour {
+				replyMessage(client, v, "⚠️ Usage: .invite every 12h / 7d / off")
+				return
+			}
+			s.InviteRotation.EveryHours = int(d / time.Hour)
+		}
+		saveGroupSettings(botID, s)
+		if s.InviteRotation.EveryHours > 0 {
+			liveInviteLink(client, v.Info.Chat)
+		}
+		replyMessage(client, v, "🔄 Link rotation: "+inviteRotationText(s.InviteRotation))
+
+	case "after":
+		n := 0
+		if val != "off" {
+			var err error
+			if n, err = strconv.Atoi(val); err != nil || n < 1 {
+				replyMessage(client, v, "⚠️ Usage: .invite after 50 / off")
+				return
+			}
+		}
+		s.InviteRotation.AfterJoins = n
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "🔄 Link rotation: "+inviteRotationText(s.InviteRotation))
+
+	default:
+		cur, err := liveInviteLink(client, v.Info.Chat)
+		if err != nil || cur == nil {
+			cur = getCurrentInvite(chatID)
+		}
+		out := "╔════════════════╗\n║ 🔗 INVITE LINKS\n╠════════════════╣\n"
+		if cur != nil {
+			out += fmt.Sprintf("║ Gen #%d (%s)\n║ %s\n║ 📅 Since %s\n║ 👥 Joins via link: %d\n",
+				cur.Gen, cur.Reason, cur.Link, cur.CreatedAt.Format("02 Jan 15:04"), cur.Joins)
+		} else {
+			out += "║ ⚠️ Link unavailable (bot not admin?)\n"
+		}
+		out += "║ 🔄 Rotation: " + inviteRotationText(s.InviteRotation) + "\n"
+
+		if hist, _ := rdb.LRange(ctx, inviteKey("history", chatID), 0, 4).Result(); len(hist) > 0 {
+			joins, _ := rdb.HGetAll(ctx, inviteKey("joins", chatID)).Result()
+			out += "╠════════════════╣\n║ 📜 Previous links\n"
+			for _, h := range hist {
+				var g InviteGen
+				if json.Unmarshal([]byte(h), &g) != nil {
+					continue
+				}
+				out += fmt.Sprintf("║ #%d — %s joins, ended: %s\n", g.Gen, orDash(joins[strconv.Itoa(g.Gen)]), g.EndReason)
+			}
+		}
+		if reasons, _ := rdb.HGetAll(ctx, inviteKey("reasons", chatID)).Result(); len(reasons) > 0 {
+			out += "╠════════════════╣\n║ 📈 Joins by source\n"
+			for r, n := range reasons {
+				out += fmt.Sprintf("║ %s: %s\n", r, n)
+			}
+		}
+		out += `╠════════════════╣
+║ .invite rotate
+║ .invite every 12h / 7d / off
+║ .invite after 50 / off
+╚════════════════╝`
+		replyMessage(client, v, out)
+	}
+}
@@ -0,0 +1,551 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ==================== جوائن ریکویسٹ آٹو اپروول ====================
+// ایڈمن اپروول والے گروپس میں پینڈنگ ریکویسٹس کو رولز کے مطابق خود
+// اپروو/ریجیکٹ کرتا ہے۔ ہر فیصلہ وجہ کے ساتھ ریڈیس میں لاگ ہوتا ہے۔
+
+const (
+	joinReqLogLimit     = 100
+	joinReqPollInterval = 2 * time.Minute
+)
+
+// JoinRequestLog ایک فیصلے کا ریکارڈ
+type JoinRequestLog struct {
+	Time     time.Time `json:"time"`
+	User     string    `json:"user"`
+	Phone    string    `json:"phone"`
+	Decision string    `json:"decision"` // approve / reject / skip
+	Reason   string    `json:"reason"`
+	By       string    `json:"by"`              // auto یا ایڈمن کا نمبر
+	Error    string    `json:"error,omitempty"` // واٹس ایپ نے ایکشن رد کیا
+}
+
+// ایک ہی گروپ پر دو بار ایک ساتھ پروسیسنگ نہ ہو (ایونٹ + پولر)
+var joinReqBusy sync.Map
+
+// ⏱️ ہر بوٹ کے لیے بیک گراؤنڈ لوپ (ریکویسٹس کا ایونٹ ہمیشہ نہیں آتا)
+func StartJoinRequestWatcher(client *whatsmeow.Client) {
+	go func() {
+		for {
+			time.Sleep(joinReqPollInterval)
+			if !isClientActive(client) {
+				return
+			}
+			if !client.IsConnected() {
+				continue
+			}
+			botID := getCleanID(client.Store.ID.User)
+			for _, s := range listBotGroupSettings(botID) {
+				if !s.JoinApproval.Enabled {
+					continue
+				}
+				jid, err := types.ParseJID(s.ChatID)
+				if err != nil {
+					continue
+				}
+				processJoinRequests(client, botID, jid)
+			}
+		}
+	}()
+}
+
+// 📩 نئی ریکویسٹ کا نوٹیفکیشن GroupInfo میں "unknown change" بن کر آتا ہے
+func hasNewJoinRequest(v *events.GroupInfo) bool {
+	for _, node := range v.UnknownChanges {
+		if node != nil && node.Tag == "created_membership_requests" {
+			return true
+		}
+	}
+	return false
+}
+
+// ⚙️ پینڈنگ لسٹ پر رولز چلائیں (صرف جب آٹو موڈ آن ہو)
+func processJoinRequests(client *whatsmeow.Client, botID string, chat types.JID) {
+	lockKey := botID + ":" + chat.String()
+	if _, busy := joinReqBusy.LoadOrStore(lockKey, true); busy {
+		return
+	}
+	defer joinReqBusy.Delete(lockKey)
+
+	s := getGroupSettings(botID, chat.String())
+	if !s.JoinApproval.Enabled {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	pending, err := client.GetGroupRequestParticipants(ctx, chat)
+	if err != nil || len(pending) == 0 {
+		return
+	}
+
+	var approve, reject []types.JID
+	var approveLogs, rejectLogs []JoinRequestLog
+	approvedToday := getJoinApprovedCount(botID, chat.String())
+
+	for _, req := range pending {
+		phone := resolvePhoneNumber(client, req.JID)
+		decision, reason := evaluateJoinRequest(client, s, req.JID, phone, approvedToday)
+		entry := JoinRequestLog{
+			Time:     time.Now(),
+			User:     req.JID.String(),
+			Phone:    phone,
+			Decision: decision,
+			Reason:   reason,
+			By:       "auto",
+		}
+
+		switch decision {
+		case "approve":
+			approve = append(approve, req.JID)
+			approveLogs = append(approveLogs, entry)
+			approvedToday++
+		case "reject":
+			reject = append(reject, req.JID)
+			rejectLogs = append(rejectLogs, entry)
+		default:
+			// ہر پاس پر وہی "skip" دوبارہ لاگ نہ ہو، صرف وجہ بدلے تو
+			if joinSkipChanged(botID, chat.String(), req.JID.String(), reason) {
+				logJoinDecision(botID, chat.String(), entry)
+			}
+		}
+	}
+
+	if len(approve) > 0 {
+		_, err := client.UpdateGroupRequestParticipants(ctx, chat, approve, whatsmeow.ParticipantChangeApprove)
+		if err != nil {
+			fmt.Printf("⚠️ [JOIN-REQ] Approve failed in %s: %v\n", chat.String(), err)
+		} else {
+			incrJoinApprovedCount(botID, chat.String(), len(approve))
+		}
+		logJoinDecisions(botID, chat.String(), approveLogs, err)
+	}
+	if len(reject) > 0 {
+		_, err := client.UpdateGroupRequestParticipants(ctx, chat, reject, whatsmeow.ParticipantChangeReject)
+		if err != nil {
+			fmt.Printf("⚠️ [JOIN-REQ] Reject failed in %s: %v\n", chat.String(), err)
+		}
+		logJoinDecisions(botID, chat.String(), rejectLogs, err)
+	}
+}
+
+// 🧮 ایک ریکویسٹ کا فیصلہ: approve / reject / skip (پینڈنگ رہنے دو)
+func evaluateJoinRequest(client *whatsmeow.Client, s *GroupSettings, user types.JID, phone string, approvedToday int) (string, string) {
+	rules := s.JoinApproval
+
+	// 1. گلوبل بین لسٹ
+	if rules.CheckBans && isGloballyBanned(client, user) {
+		return "reject", "Globally banned"
+	}
+
+	// 2. کنٹری کوڈ
+	if phone == "" {
+		if len(rules.AllowedCodes) > 0 {
+			return "skip", "Number hidden (LID), cannot check country"
+		}
+	} else {
+		for _, code := range rules.BlockedCodes {
+			if strings.HasPrefix(phone, code) {
+				return "reject", "Country code +" + code + " blocked"
+			}
+		}
+		if len(rules.AllowedCodes) > 0 {
+			allowed := false
+			for _, code := range rules.AllowedCodes {
+				if strings.HasPrefix(phone, code) {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				return "reject", "Country code not in allow list"
+			}
+		}
+	}
+
+	// 3. روزانہ کی حد (ریجیکٹ نہیں، کل کے لیے پینڈنگ رہنے دو)
+	if rules.DailyCap > 0 && approvedToday >= rules.DailyCap {
+		return "skip", fmt.Sprintf("Daily cap reached (%d)", rules.DailyCap)
+	}
+
+	return "approve", "Passed all rules"
+}
+
+// 📱 LID والے یوزر کا اصل نمبر (اگر سٹور میں موجود ہو)
+func resolvePhoneNumber(client *whatsmeow.Client, jid types.JID) string {
+	if jid.Server == types.HiddenUserServer {
+		pn, err := client.Store.LIDs.GetPNForLID(context.Background(), jid)
+		if err != nil || pn.IsEmpty() {
+			return ""
+		}
+		return getCleanNumber(pn.User)
+	}
+	return getCleanNumber(jid.User)
+}
+
+// 📊 آج کتنے اپروو ہوئے
+func joinCapKey(botID, chatID string) string {
+	return fmt.Sprintf("joinreq:count:%s:%s:%s", botID, chatID, time.Now().Format("20060102"))
+}
+
+func getJoinApprovedCount(botID, chatID string) int {
+	if rdb == nil {
+		return 0
+	}
+	n, err := rdb.Get(ctx, joinCapKey(botID, chatID)).Int()
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func incrJoinApprovedCount(botID, chatID string, n int) {
+	if rdb == nil {
+		return
+	}
+	key := joinCapKey(botID, chatID)
+	rdb.IncrBy(ctx, key, int64(n))
+	rdb.Expire(ctx, key, 48*time.Hour)
+}
+
+// 📝 فیصلہ لاگ کریں
+func logJoinDecision(botID, chatID string, entry JoinRequestLog) {
+	fmt.Printf("📥 [JOIN-REQ] Bot:%s | Group:%s | User:%s | %s (%s)\n",
+		botID, chatID, entry.User, strings.ToUpper(entry.Decision), entry.Reason)
+
+	if rdb == nil {
+		return
+	}
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	key := fmt.Sprintf("joinreq:log:%s:%s", botID, chatID)
+	rdb.LPush(ctx, key, payload)
+	rdb.LTrim(ctx, key, 0, joinReqLogLimit-1)
+}
+
+// ایکشن کے بعد لاگ (ناکام ہو تو ایرر کے ساتھ)
+func logJoinDecisions(botID, chatID string, entries []JoinRequestLog, err error) {
+	for _, entry := range entries {
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		joinSkipChanged(botID, chatID, entry.User, "")
+		logJoinDecision(botID, chatID, entry)
+	}
+}
+
+// ⏸️ پچھلی skip وجہ سے مختلف ہے؟ (خالی reason = ریکارڈ صاف)
+func joinSkipChanged(botID, chatID, user, reason string) bool {
+	if rdb == nil {
+		return true
+	}
+	key := fmt.Sprintf("joinreq:skip:%s:%s", botID, chatID)
+	if reason == "" {
+		rdb.HDel(ctx, key, user)
+		return true
+	}
+	if last, _ := rdb.HGet(ctx, key, user).Result(); last == reason {
+		return false
+	}
+	rdb.HSet(ctx, key, user, reason)
+	rdb.Expire(ctx, key, 7*24*time.Hour)
+	return true
+}
+
+func getJoinDecisionLog(botID, chatID string, n int) []JoinRequestLog {
+	if rdb == nil {
+		return nil
+	}
+	vals, err := rdb.LRange(ctx, fmt.Sprintf("joinreq:log:%s:%s", botID, chatID), 0, int64(n-1)).Result()
+	if err != nil {
+		return nil
+	}
+	var out []JoinRequestLog
+	for _, val := range vals {
+		var entry JoinRequestLog
+		if json.Unmarshal([]byte(val), &entry) == nil {
+			out = append(out, entry)
+		}
+	}
+	return out
+}
+
+// ==================== .requests کمانڈ ====================
+func handleJoinRequests(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	chatID := v.Info.Chat.String()
+	s := getGroupSettings(botID, chatID)
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	switch sub {
+	case "", "list":
+		sendJoinRequestList(client, v)
+
+	case "approve", "reject":
+		if len(args) < 2 || strings.ToLower(args[1]) != "all" {
+			replyMessage(client, v, fmt.Sprintf("⚠️ Usage: .requests %s all", sub))
+			return
+		}
+		bulkJoinRequestAction(client, v, botID, sub)
+
+	case "auto":
+		if len(args) < 2 {
+			replyMessage(client, v, "⚠️ Usage: .requests auto on | off")
+			return
+		}
+		switch strings.ToLower(args[1]) {
+		case "on":
+			s.JoinApproval.Enabled = true
+		case "off":
+			s.JoinApproval.Enabled = false
+		default:
+			replyMessage(client, v, "⚠️ Usage: .requests auto on | off")
+			return
+		}
+		saveGroupSettings(botID, s)
+		sendJoinRulesCard(client, v, s)
+		if s.JoinApproval.Enabled {
+			go processJoinRequests(client, botID, v.Info.Chat)
+		}
+
+	case "allow", "block":
+		codes := parseCountryCodes(args[1:])
+		if sub == "allow" {
+			s.JoinApproval.AllowedCodes = codes
+		} else {
+			s.JoinApproval.BlockedCodes = codes
+		}
+		saveGroupSettings(botID, s)
+		sendJoinRulesCard(client, v, s)
+
+	case "cap":
+		if len(args) < 2 {
+			replyMessage(client, v, "⚠️ Usage: .requests cap <number> (0 = no limit)")
+			return
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 0 {
+			replyMessage(client, v, "❌ Invalid number.")
+			return
+		}
+		s.JoinApproval.DailyCap = n
+		saveGroupSettings(botID, s)
+		sendJoinRulesCard(client, v, s)
+
+	case "bans":
+		if len(args) < 2 || (args[1] != "on" && args[1] != "off") {
+			replyMessage(client, v, "⚠️ Usage: .requests bans on | off")
+			return
+		}
+		s.JoinApproval.CheckBans = args[1] == "on"
+		saveGroupSettings(botID, s)
+		sendJoinRulesCard(client, v, s)
+
+	case "rules":
+		sendJoinRulesCard(client, v, s)
+
+	case "log":
+		sendJoinDecisionLog(client, v, botID)
+
+	default:
+		replyMessage(client, v, `╔════════════════╗
+║ 📥 JOIN REQUESTS
+╠════════════════
+║ .requests list
+║ .requests approve all
+║ .requests reject all
+║ .requests auto on/off
+║ .requests allow 92 91
+║ .requests block 234
+║ .requests cap 50
+║ .requests bans on/off
+║ .requests rules
+║ .requests log
+╚════════════════`)
+	}
+}
+
+// "+92, 91" جیسے ان پٹ کو ["92","91"] بنائیں ("off" یا خالی = لسٹ صاف)
+func parseCountryCodes(args []string) []string {
+	var codes []string
+	for _, a := range strings.Fields(strings.ReplaceAll(strings.Join(args, " "), ",", " ")) {
+		a = strings.TrimPrefix(strings.TrimSpace(a), "+")
+		if a == "" || strings.EqualFold(a, "off") {
+			continue
+		}
+		if _, err := strconv.Atoi(a); err == nil {
+			codes = append(codes, a)
+		}
+	}
+	return codes
+}
+
+func sendJoinRequestList(client *whatsmeow.Client, v *events.Message) {
+	pending, err := client.GetGroupRequestParticipants(context.Background(), v.Info.Chat)
+	if err != nil {
+		replyMessage(client, v, "⚠️ Failed to fetch requests (Give me Admin Rights)")
+		return
+	}
+	if len(pending) == 0 {
+		replyMessage(client, v, "📭 No pending join requests.")
+		return
+	}
+
+	out := "╔════════════════╗\n║ 📥 PENDING REQUESTS\n╠════════════════\n"
+	for i, req := range pending {
+		if i >= 30 {
+			out += fmt.Sprintf("║ ... +%d more\n", len(pending)-30)
+			break
+		}
+		num := resolvePhoneNumber(client, req.JID)
+		if num == "" {
+			num = req.JID.User + " (LID)"
+		}
+		out += fmt.Sprintf("║ %d. +%s\n║    ⏰ %s\n", i+1, num, req.RequestedAt.Format("02 Jan 15:04"))
+	}
+	out += fmt.Sprintf("║ 👥 Total: %d\n╚════════════════", len(pending))
+	replyMessage(client, v, out)
+}
+
+func bulkJoinRequestAction(client *whatsmeow.Client, v *events.Message, botID, action string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pending, err := client.GetGroupRequestParticipants(ctx, v.Info.Chat)
+	if err != nil {
+		replyMessage(client, v, "⚠️ Failed to fetch requests (Give me Admin Rights)")
+		return
+	}
+	if len(pending) == 0 {
+		replyMessage(client, v, "📭 No pending join requests.")
+		return
+	}
+
+	jids := make([]types.JID, 0, len(pending))
+	for _, req := range pending {
+		jids = append(jids, req.JID)
+	}
+
+	change := whatsmeow.ParticipantChangeApprove
+	decision, title := "approve", "✅ APPROVED"
+	if action == "reject" {
+		change = whatsmeow.ParticipantChangeReject
+		decision, title = "reject", "🚫 REJECTED"
+	}
+
+	if _, err := client.UpdateGroupRequestParticipants(ctx, v.Info.Chat, jids, change); err != nil {
+		replyMessage(client, v, "⚠️ Action failed: "+err.Error())
+		return
+	}
+	if decision == "approve" {
+		incrJoinApprovedCount(botID, v.Info.Chat.String(), len(jids))
+	}
+
+	admin := v.Info.Sender.User
+	for _, jid := range jids {
+		logJoinDecision(botID, v.Info.Chat.String(), JoinRequestLog{
+			Time:     time.Now(),
+			User:     jid.String(),
+			Phone:    resolvePhoneNumber(client, jid),
+			Decision: decision,
+			Reason:   "Manual bulk " + decision,
+			By:       admin,
+		})
+	}
+
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ %s
+╠════════════════
+║ Requests: %d
+║ By: @%s
+╚════════════════`, title, len(jids), admin))
+}
+
+func sendJoinRulesCard(client *whatsmeow.Client, v *events.Message, s *GroupSettings) {
+	rules := s.JoinApproval
+
+	status := "🔴 DISABLED"
+	if rules.Enabled {
+		status = "🟢 ENABLED"
+	}
+	allow := "All countries"
+	if len(rules.AllowedCodes) > 0 {
+		allow = "+" + strings.Join(rules.AllowedCodes, ", +")
+	}
+	block := "None"
+	if len(rules.BlockedCodes) > 0 {
+		block = "+" + strings.Join(rules.BlockedCodes, ", +")
+	}
+	limit := "No limit"
+	if rules.DailyCap > 0 {
+		limit = fmt.Sprintf("%d/day (today: %d)", rules.DailyCap, getJoinApprovedCount(getCleanID(client.Store.ID.User), s.ChatID))
+	}
+	bans := "❌ NO"
+	if rules.CheckBans {
+		bans = "✅ YES"
+	}
+
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 📥 AUTO APPROVAL
+╠════════════════
+║ Status: %s
+║ Allow: %s
+║ Block: %s
+║ Cap: %s
+║ Ban Check: %s
+╚════════════════`, status, allow, block, limit, bans))
+}
+
+func sendJoinDecisionLog(client *whatsmeow.Client, v *events.Message, botID string) {
+	entries := getJoinDecisionLog(botID, v.Info.Chat.String(), 15)
+	if len(entries) == 0 {
+		replyMessage(client, v, "📭 No decisions logged yet.")
+		return
+	}
+
+	icons := map[string]string{"approve": "✅", "reject": "🚫", "skip": "⏸️"}
+	out := "╔════════════════╗\n║ 📜 REQUEST LOG\n╠════════════════\n"
+	for _, e := range entries {
+		who := e.Phone
+		if who == "" {
+			who = strings.Split(e.User, "@")[0]
+		}
+		icon, reason := icons[e.Decision], e.Reason
+		if e.Error != "" {
+			icon, reason = "⚠️", e.Decision+" failed: "+e.Error
+		}
+		out += fmt.Sprintf("║ %s +%s\n║    %s | %s\n", icon, who, reason, e.Time.Format("02 Jan 15:04"))
+	}
+	out += "╚════════════════"
+	replyMessage(client, v, out)
+}
@@ -198,6 +198,7 @@ func main() {
 				chatHistoryCollection = db.Collection("messages")
 				mediaCollection = db.Collection("media")
 				statusCollection = db.Collection("statuses")
+				modLogCollection = db.Collection("modlog")
 
 				fmt.Println("🍃 [MONGODB] Connected for Chat History + Media + Status!")
 
@@ -275,6 +276,29 @@ func main() {
 						})
 					}
 
+					// ----------------------------
+					// MODLOG Indexes
+					// ----------------------------
+					if modLogCollection != nil {
+						_, _ = modLogCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+							{
+								Keys: bson.D{
+									{Key: "bot_id", Value: 1},
+									{Key: "group_id", Value: 1},
+									{Key: "timestamp", Value: -1},
+								},
+							},
+							{
+								Keys: bson.D{
+									{Key: "bot_id", Value: 1},
+									{Key: "group_id", Value: 1},
+									{Key: "target", Value: 1},
+									{Key: "timestamp", Value: -1},
+								},
+							},
+						})
+					}
+
 					fmt.Println("✅ [MONGODB] Indexes ensured!")
 				}()
 			}
@@ -349,6 +373,9 @@ func main() {
 	// ✅ Status APIs (route now)
 	http.HandleFunc("/api/statuses", handleGetStatuses)
 
+	// ✅ Moderation Audit Log (dashboard)
+	http.HandleFunc("/api/modlog", handleGetModLog)
+
 	// ----------------------------------------------------
 	// ✅ Health / Ready
 	// ----------------------------------------------------
@@ -820,7 +847,7 @@ func ConnectNewSession(device *store.Device) {
 		fmt.Printf("❌ [CONNECT ERROR] Bot %s: %v\n", cleanID, err)
 		return
 	}
-	go StartKeepAliveLoop(newBotClient)
+	startBotLoops(newBotClient)
 	clientsMutex.Lock()
 	activeClients[cleanID] = newBotClient
 	clientsMutex.Unlock()
@@ -828,6 +855,42 @@ func ConnectNewSession(device *store.Device) {
 	fmt.Printf("✅ [CONNECTED] Bot: %s | Prefix: %s | Status: Ready\n", cleanID, p)
 }
 
+// 🔁 ہر بوٹ کے بیک گراؤنڈ لوپس (ConnectNewSession اور نئی پیئرنگ دونوں سے)
+func startBotLoops(client *whatsmeow.Client) {
+	go StartKeepAliveLoop(client)
+	StartJoinRequestWatcher(client)
+	StartNightModeScheduler(client)
+	StartRaidScheduler(client)
+	StartRosterReconciler(client)
+	StartBroadcastResumer(client)
+	StartInviteRotator(client)
+}
+
+// 🔌 کیا یہ کلائنٹ ابھی بھی ایکٹو ہے؟ (ڈیلیٹ یا ری پیئر کے بعد پرانے بیک گراؤنڈ لوپس رک جائیں)
+func isClientActive(client *whatsmeow.Client) bool {
+	if client == nil || client.Store.ID == nil {
+		return false
+	}
+	clientsMutex.RLock()
+	defer clientsMutex.RUnlock()
+	return activeClients[getCleanID(client.Store.ID.User)] == client
+}
+
+// 🤖 کیا یہ JID اس ڈیپلائمنٹ کے کسی ایکٹو بوٹ کا ہے؟ (فون یا LID)
+func isDeploymentBot(user types.JID) bool {
+	clientsMutex.RLock()
+	defer clientsMutex.RUnlock()
+	for _, c := range activeClients {
+		if c.Store.ID != nil && user.User == c.Store.ID.User {
+			return true
+		}
+		if !c.Store.LID.IsEmpty() && user.User == c.Store.LID.User {
+			return true
+		}
+	}
+	return false
+}
+
 func StartKeepAliveLoop(client *whatsmeow.Client) {
 	go func() {
 		for {
@@ -1019,6 +1082,7 @@ func handlePairAPI(w http.ResponseWriter, r *http.Request) {
 				clientsMutex.Lock()
 				activeClients[cleanNum] = tempClient
 				clientsMutex.Unlock()
+				startBotLoops(tempClient)
 				return
 			}
 		}
@@ -1126,6 +1190,8 @@ func PreloadAllGroupSettings() {
 		if err == nil {
 			var s GroupSettings
 			if json.Unmarshal([]byte(val), &s) == nil {
+				migrateMediaRules(&s)
+				migrateGreetings(&s)
 				parts := strings.Split(key, ":")
 				if len(parts) >= 3 {
 					uniqueKey := parts[1] + ":" + parts[2]
@@ -1154,6 +1220,8 @@ func getGroupSettings(botID, chatID string) *GroupSettings {
 		if err == nil {
 			var loadedSettings GroupSettings
 			if json.Unmarshal([]byte(val), &loadedSettings) == nil {
+				migrateMediaRules(&loadedSettings)
+				migrateGreetings(&loadedSettings)
 				cacheMutex.Lock()
 				groupCache[uniqueKey] = &loadedSettings
 				cacheMutex.Unlock()
@@ -1164,6 +1232,7 @@ func getGroupSettings(botID, chatID string) *GroupSettings {
 	return &GroupSettings{
 		ChatID: chatID, Mode: "public", Antilink: false,
 		AntilinkAdmin: true, AntilinkAction: "delete", Welcome: false,
+		MediaRules: map[string]*MediaRule{},
 	}
 }
 
@@ -1184,6 +1253,23 @@ func saveGroupSettings(botID string, s *GroupSettings) {
 	}
 }
 
+// 📋 ایک بوٹ کے تمام گروپس کی سیٹنگز (بیک گراؤنڈ لوپس کے لیے)
+func listBotGroupSettings(botID string) []*GroupSettings {
+	if rdb == nil {
+		return nil
+	}
+	prefix := "group_settings:" + botID + ":"
+	keys, err := rdb.Keys(ctx, prefix+"*").Result()
+	if err != nil {
+		return nil
+	}
+	var out []*GroupSettings
+	for _, key := range keys {
+		out = append(out, getGroupSettings(botID, strings.TrimPrefix(key, prefix)))
+	}
+	return out
+}
+
 func monitorNewSessions(container *sqlstore.Container) {
 	ticker := time.NewTicker(60 * time.Second)
 	defer ticker.Stop()
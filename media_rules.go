@@ -0,0 +1,239 @@
+package main
+
+import (
+	"strings"
+
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ==================== میڈیا رولز (ہر میڈیا ٹائپ کے لیے) ====================
+// media type → enabled, admin bypass, action, reason
+// پرانے AntiPic/AntiVideo/AntiSticker فیلڈز پہلی لوڈ پر یہاں منتقل ہو جاتے ہیں
+
+// MediaRuleDef ایک میڈیا رول کی تعریف (کمانڈ، نام، ڈیفالٹ وجہ)
+type MediaRuleDef struct {
+	Type    string // image, video, sticker ...
+	Command string // antipic, antivideo ...
+	Label   string // کارڈز میں دکھانے کے لیے
+	Emoji   string
+	Reason  string // ڈیفالٹ وجہ
+}
+
+// ترتیب اہم ہے: پہلے مخصوص ٹائپس (viewonce, apk) پھر عام
+var mediaRuleDefs = []MediaRuleDef{
+	{Type: "viewonce", Command: "antiviewonce", Label: "View-Once Media", Emoji: "👁️", Reason: "View-once media not allowed"},
+	{Type: "apk", Command: "antiapk", Label: "APK Files", Emoji: "📦", Reason: "APK files not allowed"},
+	{Type: "image", Command: "antipic", Label: "Images", Emoji: "🖼️", Reason: "Image not allowed"},
+	{Type: "video", Command: "antivideo", Label: "Videos", Emoji: "🎥", Reason: "Video not allowed"},
+	{Type: "sticker", Command: "antisticker", Label: "Stickers", Emoji: "🚫", Reason: "Sticker not allowed"},
+	{Type: "document", Command: "antidoc", Label: "Documents", Emoji: "📄", Reason: "Documents not allowed"},
+	{Type: "voice", Command: "antivoice", Label: "Voice Notes", Emoji: "🎙️", Reason: "Voice notes not allowed"},
+	{Type: "poll", Command: "antipoll", Label: "Polls", Emoji: "📊", Reason: "Polls not allowed"},
+	{Type: "contact", Command: "anticontact", Label: "Contacts", Emoji: "👤", Reason: "Contacts not allowed"},
+	{Type: "location", Command: "antilocation", Label: "Locations", Emoji: "📍", Reason: "Locations not allowed"},
+}
+
+func mediaRuleDefByType(t string) *MediaRuleDef {
+	for i := range mediaRuleDefs {
+		if mediaRuleDefs[i].Type == t {
+			return &mediaRuleDefs[i]
+		}
+	}
+	return nil
+}
+
+func mediaRuleDefByCommand(cmd string) *MediaRuleDef {
+	for i := range mediaRuleDefs {
+		if mediaRuleDefs[i].Command == cmd {
+			return &mediaRuleDefs[i]
+		}
+	}
+	return nil
+}
+
+// 🔁 پرانی سیٹنگز کو نئے ماڈل میں منتقل کریں (پرانا رویہ: صرف ڈیلیٹ، اینٹی لنک والا بائی پاس)
+func migrateMediaRules(s *GroupSettings) {
+	if s.MediaRules != nil {
+		return
+	}
+	s.MediaRules = make(map[string]*MediaRule)
+	legacy := map[string]bool{"image": s.AntiPic, "video": s.AntiVideo, "sticker": s.AntiSticker}
+	for t, enabled := range legacy {
+		if !enabled {
+			continue
+		}
+		s.MediaRules[t] = &MediaRule{
+			Enabled:     true,
+			AdminBypass: s.AntilinkAdmin,
+			Action:      "delete",
+			Reason:      mediaRuleDefByType(t).Reason,
+		}
+	}
+}
+
+// رول نکالیں، نہ ہو تو ڈیفالٹ بنا دیں (لکھنے سے پہلے استعمال کریں)
+func ensureMediaRule(s *GroupSettings, t string) *MediaRule {
+	migrateMediaRules(s)
+	r, ok := s.MediaRules[t]
+	if !ok || r == nil {
+		r = &MediaRule{AdminBypass: true, Action: "delete"}
+		if def := mediaRuleDefByType(t); def != nil {
+			r.Reason = def.Reason
+		}
+		s.MediaRules[t] = r
+	}
+	return r
+}
+
+// پڑھنے کے لیے (nil = رول موجود نہیں / بند)
+func getMediaRule(s *GroupSettings, t string) *MediaRule {
+	if s.MediaRules == nil {
+		return nil
+	}
+	return s.MediaRules[t]
+}
+
+// 🔍 میسج میں کون کون سی میڈیا ٹائپس ہیں (mediaRuleDefs کی ترتیب میں)
+func detectMediaTypes(v *events.Message) []string {
+	m := v.Message
+	if m == nil {
+		return nil
+	}
+	var found []string
+
+	if v.IsViewOnce || m.GetImageMessage().GetViewOnce() || m.GetVideoMessage().GetViewOnce() || m.GetAudioMessage().GetViewOnce() {
+		found = append(found, "viewonce")
+	}
+	if doc := m.GetDocumentMessage(); doc != nil {
+		if doc.GetMimetype() == "application/vnd.android.package-archive" ||
+			strings.HasSuffix(strings.ToLower(doc.GetFileName()), ".apk") {
+			found = append(found, "apk")
+		}
+	}
+	if m.ImageMessage != nil {
+		found = append(found, "image")
+	}
+	if m.VideoMessage != nil {
+		found = append(found, "video")
+	}
+	if m.StickerMessage != nil {
+		found = append(found, "sticker")
+	}
+	if m.DocumentMessage != nil {
+		found = append(found, "document")
+	}
+	if m.GetAudioMessage().GetPTT() {
+		found = append(found, "voice")
+	}
+	if m.PollCreationMessage != nil || m.PollCreationMessageV2 != nil || m.PollCreationMessageV3 != nil {
+		found = append(found, "poll")
+	}
+	if m.ContactMessage != nil || m.ContactsArrayMessage != nil {
+		found = append(found, "contact")
+	}
+	if m.LocationMessage != nil || m.LiveLocationMessage != nil {
+		found = append(found, "location")
+	}
+	return found
+}
+
+// ⚡ پہلا فعال رول جو اس میسج پر لاگو ہوتا ہے
+func matchMediaRule(s *GroupSettings, v *events.Message) (*MediaRuleDef, *MediaRule) {
+	for _, t := range detectMediaTypes(v) {
+		if r := getMediaRule(s, t); r != nil && r.Enabled {
+			return mediaRuleDefByType(t), r
+		}
+	}
+	return nil, nil
+}
+
+// 👮 کس رول میں ایڈمنز کو چھوٹ ہے
+func securityRuleAdminBypass(s *GroupSettings, rule string) bool {
+	if rule == "antilink" {
+		return s.AntilinkAdmin
+	}
+	if rule == "antiforward" {
+		return s.AntiForward.AdminBypass
+	}
+	if def := mediaRuleDefByCommand(rule); def != nil {
+		if r := getMediaRule(s, def.Type); r != nil {
+			return r.AdminBypass
+		}
+	}
+	return false
+}
+
+// ایکشن کا پڑھنے کے قابل نام
+func securityActionText(action string) string {
+	switch action {
+	case "deletekick":
+		return "Delete + Kick"
+	case "deletewarn":
+		return "Delete + Warn"
+	}
+	return "Delete Only"
+}
+
+// ==================== سیکیورٹی رول ہیلپرز (antilink + antiforward + میڈیا) ====================
+
+// وزرڈ/کارڈز کے لیے رول کا نام (جیسے "links", "images")
+func securityRuleLabel(secType string) string {
+	if secType == "antilink" {
+		return "links"
+	}
+	if secType == "antiforward" {
+		return "forwarded messages"
+	}
+	if def := mediaRuleDefByCommand(secType); def != nil {
+		return strings.ToLower(def.Label)
+	}
+	return secType
+}
+
+// 📊 رول کی موجودہ حالت
+func securityRuleState(s *GroupSettings, secType string) (enabled, bypass bool, action, reason string) {
+	if secType == "antilink" {
+		return s.Antilink, s.AntilinkAdmin, s.AntilinkAction, "Link detected"
+	}
+	if secType == "antiforward" {
+		f := s.AntiForward
+		return f.Enabled, f.AdminBypass, f.Action, "Forwarded message not allowed"
+	}
+	def := mediaRuleDefByCommand(secType)
+	if def == nil {
+		return false, false, "", ""
+	}
+	r := getMediaRule(s, def.Type)
+	if r == nil {
+		return false, true, "delete", def.Reason
+	}
+	return r.Enabled, r.AdminBypass, r.Action, r.Reason
+}
+
+func setSecurityRuleAdmin(s *GroupSettings, secType string, bypass bool) {
+	if secType == "antilink" {
+		s.AntilinkAdmin = bypass
+		return
+	}
+	if secType == "antiforward" {
+		s.AntiForward.AdminBypass = bypass
+		return
+	}
+	if def := mediaRuleDefByCommand(secType); def != nil {
+		ensureMediaRule(s, def.Type).AdminBypass = bypass
+	}
+}
+
+func setSecurityRuleAction(s *GroupSettings, secType, action string) {
+	if secType == "antilink" {
+		s.AntilinkAction = action
+		return
+	}
+	if secType == "antiforward" {
+		s.AntiForward.Action = action
+		return
+	}
+	if def := mediaRuleDefByCommand(secType); def != nil {
+		ensureMediaRule(s, def.Type).Action = action
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types/events"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ==================== ماڈریشن آڈٹ لاگ ====================
+// ہر ڈیلیٹ/وارن/کک (آٹو یا مینول) مونگو کی "modlog" کلیکشن میں محفوظ ہوتا ہے
+
+var modLogCollection *mongo.Collection
+
+// ModLogEntry ایک ماڈریشن ایکشن کا ریکارڈ
+type ModLogEntry struct {
+	BotID     string    `bson:"bot_id" json:"bot_id"`
+	GroupID   string    `bson:"group_id" json:"group_id"`
+	Actor     string    `bson:"actor" json:"actor"`   // "bot" یا ایڈمن کا نمبر
+	Target    string    `bson:"target" json:"target"` // جس پر ایکشن ہوا
+	Action    string    `bson:"action" json:"action"` // delete / warn / kick / promote / demote
+	Rule      string    `bson:"rule" json:"rule"`     // antilink / antipic / manual ...
+	Reason    string    `bson:"reason" json:"reason"`
+	Excerpt   string    `bson:"excerpt,omitempty" json:"excerpt,omitempty"`
+	MessageID string    `bson:"message_id,omitempty" json:"message_id,omitempty"`
+	Auto      bool      `bson:"auto" json:"auto"`
+	Timestamp time.Time `bson:"timestamp" json:"timestamp"`
+}
+
+const modLogExcerptLen = 120
+
+// 💾 لاگ محفوظ کریں (مونگو نہ ہو تو صرف کنسول)
+func logModAction(entry ModLogEntry) {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	fmt.Printf("📒 [MODLOG] Bot:%s | Group:%s | %s -> %s | %s (%s)\n",
+		entry.BotID, entry.GroupID, entry.Actor, entry.Target, strings.ToUpper(entry.Action), entry.Rule)
+	markAppealable(entry)
+
+	if modLogCollection == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if _, err := modLogCollection.InsertOne(ctx, entry); err != nil {
+			fmt.Printf("❌ [MODLOG] Insert failed: %v\n", err)
+		}
+	}()
+}
+
+// 🛡️ آٹو سیکیورٹی ایکشن کا لاگ (takeSecurityAction سے)
+func logAutoModAction(botID string, v *events.Message, action, rule, reason string) {
+	logModAction(ModLogEntry{
+		BotID:     botID,
+		GroupID:   v.Info.Chat.String(),
+		Actor:     "bot",
+		Target:    getCleanID(v.Info.Sender.User),
+		Action:    action,
+		Rule:      rule,
+		Reason:    reason,
+		Excerpt:   messageExcerpt(v.Message),
+		MessageID: v.Info.ID,
+		Auto:      true,
+	})
+}
+
+// 👮 ایڈمن کی مینول کمانڈ کا لاگ (.kick / .del / .promote ...)
+func logManualModAction(client *whatsmeow.Client, v *events.Message, action, target, excerpt, messageID string) {
+	logModAction(ModLogEntry{
+		BotID:     getCleanID(client.Store.ID.User),
+		GroupID:   v.Info.Chat.String(),
+		Actor:     getCleanID(v.Info.Sender.User),
+		Target:    target,
+		Action:    action,
+		Rule:      "manual",
+		Reason:    "." + action + " command",
+		Excerpt:   excerpt,
+		MessageID: messageID,
+	})
+}
+
+// ✂️ میسج کا چھوٹا سا حصہ (میڈیا ہو تو اس کی قسم)
+func messageExcerpt(m *waProto.Message) string {
+	if m == nil {
+		return ""
+	}
+	text := getText(m)
+	if text == "" {
+		switch {
+		case m.ImageMessage != nil:
+			text = "[image]"
+		case m.VideoMessage != nil:
+			text = "[video]"
+		case m.StickerMessage != nil:
+			text = "[sticker]"
+		case m.AudioMessage != nil:
+			text = "[audio]"
+		case m.DocumentMessage != nil:
+			text = "[document] " + m.DocumentMessage.GetFileName()
+		default:
+			text = "[message]"
+		}
+	}
+	runes := []rune(text)
+	if len(runes) > modLogExcerptLen {
+		text = string(runes[:modLogExcerptLen]) + "…"
+	}
+	return text
+}
+
+// 🔍 فلٹر کے ساتھ لاگ نکالیں (نئے پہلے)
+func queryModLog(filter bson.M, limit int64) ([]ModLogEntry, error) {
+	if modLogCollection == nil {
+		return nil, fmt.Errorf("mongodb not connected")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: -1}}).SetLimit(limit)
+	cur, err := modLogCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var out []ModLogEntry
+	if err := cur.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+const modLogMaxRows = 30
+
+// 🔢 آخری چھوٹا نمبر (≤30) گنتی ہے، فون نمبر نہیں: .modlog 20 / .modlog @user 5
+func splitModLogCount(args []string) ([]string, int64) {
+	if n := len(args); n > 0 {
+		if c, err := strconv.Atoi(args[n-1]); err == nil && c > 0 && c <= modLogMaxRows {
+			return args[:n-1], int64(c)
+		}
+	}
+	return args, 10
+}
+
+// ==================== .modlog [@user] [n] ====================
+func handleModLog(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	filter := bson.M{"bot_id": botID, "group_id": v.Info.Chat.String()}
+
+	args, limit := splitModLogCount(args)
+	target, rest := extractTargetJID(v, args)
+	if !target.IsEmpty() {
+		filter["target"] = getCleanID(target.User)
+	}
+	if len(rest) > 0 {
+		if n, err := strconv.Atoi(rest[0]); err == nil && n > 0 {
+			limit = min(int64(n), modLogMaxRows)
+		}
+	}
+
+	entries, err := queryModLog(filter, limit)
+	if err != nil {
+		replyMessage(client, v, "⚠️ Mod log unavailable: "+err.Error())
+		return
+	}
+	if len(entries) == 0 {
+		replyMessage(client, v, "📭 No moderation actions logged yet.")
+		return
+	}
+
+	icons := map[string]string{
+		"delete": "🗑️", "warn": "⚠️", "kick": "👢", "promote": "⬆️", "demote": "⬇️", "linkrotate": "🔗",
+	}
+	out := "╔════════════════╗\n║ 📒 MOD LOG\n╠════════════════\n"
+	for _, e := range entries {
+		icon := icons[e.Action]
+		if icon == "" {
+			icon = "•"
+		}
+		by := "🤖 Bot"
+		if !e.Auto {
+			by = "👮 " + e.Actor
+		}
+		out += fmt.Sprintf("║ %s %s | %s\n║    👤 %s | %s\n║    📌 %s (%s)\n",
+			icon, strings.ToUpper(e.Action), e.Timestamp.Format("02 Jan 15:04"),
+			e.Target, by, e.Reason, e.Rule)
+		if e.Excerpt != "" {
+			out += "║    💬 " + e.Excerpt + "\n"
+		}
+	}
+	out += "╚════════════════"
+	replyMessage(client, v, out)
+}
+
+// ==================== /api/modlog ====================
+// فلٹرز: bot_id, group_id, actor, target, action, rule, auto, since, until (RFC3339), limit
+func handleGetModLog(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Content-Type", "application/json")
+
+	if modLogCollection == nil {
+		http.Error(w, "MongoDB not connected", http.StatusInternalServerError)
+		return
+	}
+
+	q := r.URL.Query()
+	filter := bson.M{}
+	for _, key := range []string{"bot_id", "group_id", "actor", "target", "action", "rule"} {
+		if val := strings.TrimSpace(q.Get(key)); val != "" {
+			if key == "bot_id" || key == "actor" || key == "target" {
+				val = getCleanID(val)
+			}
+			filter[key] = val
+		}
+	}
+	if val := q.Get("auto"); val != "" {
+		filter["auto"] = val == "true" || val == "1"
+	}
+
+	tsFilter := bson.M{}
+	if val := q.Get("since"); val != "" {
+		if t, err := time.Parse(time.RFC3339, val); err == nil {
+			tsFilter["$gte"] = t
+		}
+	}
+	if val := q.Get("until"); val != "" {
+		if t, err := time.Parse(time.RFC3339, val); err == nil {
+			tsFilter["$lte"] = t
+		}
+	}
+	if len(tsFilter) > 0 {
+		filter["timestamp"] = tsFilter
+	}
+
+	limit := int64(100)
+	if s := q.Get("limit"); s != "" {
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil && n > 0 && n <= 500 {
+			limit = n
+		}
+	}
+
+	entries, err := queryModLog(filter, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if entries == nil {
+		entries = []ModLogEntry{}
+	}
+	_ = json.NewEncoder(w).Encode(entries)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitModLogCount(t *testing.T) {
+	tests := []struct {
+		args  string
+		rest  string
+		limit int64
+	}{
+		{"", "", 10},
+		{"20", "", 20},
+		{"30", "", 30},
+		{"@923001234567", "@923001234567", 10},
+		{"@923001234567 5", "@923001234567", 5},
+		{"923001234567", "923001234567", 10},
+		{"923001234567 15", "923001234567", 15},
+		{"45", "45", 10}, // 30 سے زیادہ: گنتی نہیں
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			rest, limit := splitModLogCount(strings.Fields(tt.args))
+			if got := strings.Join(rest, " "); got != tt.rest || limit != tt.limit {
+				t.Fatalf("got (%q, %d), want (%q, %d)", got, limit, tt.rest, tt.limit)
+			}
+		})
+	}
+}
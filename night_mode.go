@@ -0,0 +1,408 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // کنٹینر میں zoneinfo نہ ہو تب بھی ٹائم زون لوڈ ہوں
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ==================== نائٹ موڈ (شیڈولڈ گروپ کلوز/اوپن) ====================
+// شیڈول گروپ کے حساب سے ریڈیس میں محفوظ ہوتا ہے (بوٹ کے حساب سے نہیں)
+// تاکہ گروپ میں موجود کوئی بھی ایڈمن بوٹ اسے اپلائی کر سکے، ریڈیس لاک سے ایک ہی بوٹ چلاتا ہے
+
+const (
+	nightModeKeyPrefix   = "nightmode:sched:"
+	nightModeLockPrefix  = "nightmode:lock:"
+	nightModeTick        = 30 * time.Second
+	nightModeDefaultZone = "Asia/Karachi"
+)
+
+// NightModeSchedule ایک گروپ کا نائٹ موڈ شیڈول
+type NightModeSchedule struct {
+	ChatID   string `json:"chat_id"`
+	Enabled  bool   `json:"enabled"`
+	Close    string `json:"close"`     // "23:00"
+	Open     string `json:"open"`      // "07:00"
+	Days     []int  `json:"days"`      // time.Weekday (0=Sun)، خالی = ہر دن
+	Timezone string `json:"timezone"`  // IANA جیسے Asia/Karachi
+	CloseMsg string `json:"close_msg"` // خالی = ڈیفالٹ کارڈ
+	OpenMsg  string `json:"open_msg"`
+	State    string `json:"state"` // آخری اپلائی شدہ حالت: open / closed
+	SetBy    string `json:"set_by"`
+}
+
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// 💾 ریڈیس
+func getNightMode(chatID string) *NightModeSchedule {
+	if rdb == nil {
+		return nil
+	}
+	val, err := rdb.Get(ctx, nightModeKeyPrefix+chatID).Result()
+	if err != nil {
+		return nil
+	}
+	var sc NightModeSchedule
+	if json.Unmarshal([]byte(val), &sc) != nil {
+		return nil
+	}
+	return &sc
+}
+
+func saveNightMode(sc *NightModeSchedule) {
+	if rdb == nil {
+		return
+	}
+	payload, _ := json.Marshal(sc)
+	if err := rdb.Set(ctx, nightModeKeyPrefix+sc.ChatID, payload, 0).Err(); err != nil {
+		fmt.Printf("⚠️ [NIGHTMODE] Save failed for %s: %v\n", sc.ChatID, err)
+	}
+}
+
+func listNightModes() []*NightModeSchedule {
+	if rdb == nil {
+		return nil
+	}
+	keys, err := rdb.Keys(ctx, nightModeKeyPrefix+"*").Result()
+	if err != nil {
+		return nil
+	}
+	var out []*NightModeSchedule
+	for _, key := range keys {
+		if sc := getNightMode(strings.TrimPrefix(key, nightModeKeyPrefix)); sc != nil {
+			out = append(out, sc)
+		}
+	}
+	return out
+}
+
+// "23:00" → گھنٹے، منٹ
+func parseClock(s string) (int, int, bool) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, 0, false
+	}
+	return t.Hour(), t.Minute(), true
+}
+
+func nightModeLocation(sc *NightModeSchedule) *time.Location {
+	loc, err := time.LoadLocation(sc.Timezone)
+	if err != nil || sc.Timezone == "" {
+		loc, _ = time.LoadLocation(nightModeDefaultZone)
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	return loc
+}
+
+func nightModeDayAllowed(days []int, d time.Weekday) bool {
+	if len(days) == 0 {
+		return true
+	}
+	for _, x := range days {
+		if time.Weekday(x) == d {
+			return true
+		}
+	}
+	return false
+}
+
+// 🌙 اس وقت گروپ کی حالت کیا ہونی چاہیے؟ (کلوز کا دن Days میں ہو تو پوری رات بند)
+func nightModeDesiredState(sc *NightModeSchedule, now time.Time) string {
+	ch, cm, ok1 := parseClock(sc.Close)
+	oh, om, ok2 := parseClock(sc.Open)
+	if !ok1 || !ok2 {
+		return "open"
+	}
+	loc := nightModeLocation(sc)
+	t := now.In(loc)
+	for _, offset := range []int{0, -1} {
+		d := t.AddDate(0, 0, offset)
+		start := time.Date(d.Year(), d.Month(), d.Day(), ch, cm, 0, 0, loc)
+		end := time.Date(d.Year(), d.Month(), d.Day(), oh, om, 0, 0, loc)
+		if !end.After(start) {
+			end = end.AddDate(0, 0, 1)
+		}
+		if !t.Before(start) && t.Before(end) && nightModeDayAllowed(sc.Days, start.Weekday()) {
+			return "closed"
+		}
+	}
+	return "open"
+}
+
+// 👮 کیا یہ بوٹ گروپ میں ایڈمن ہے؟ (فون اور LID دونوں آئی ڈیز چیک)
+func botIsGroupAdmin(client *whatsmeow.Client, chat types.JID) bool {
+	if client.Store.ID == nil {
+		return false
+	}
+	if isAdmin(client, chat, *client.Store.ID) {
+		return true
+	}
+	return !client.Store.LID.IsEmpty() && isAdmin(client, chat, client.Store.LID)
+}
+
+// ⏰ بیک گراؤنڈ لوپ (ہر بوٹ کے لیے، ConnectNewSession سے)
+func StartNightModeScheduler(client *whatsmeow.Client) {
+	go func() {
+		for {
+			time.Sleep(nightModeTick)
+			if !isClientActive(client) {
+				return
+			}
+			if !client.IsConnected() {
+				continue
+			}
+			now := time.Now()
+			for _, sc := range listNightModes() {
+				if !sc.Enabled {
+					continue
+				}
+				if desired := nightModeDesiredState(sc, now); desired != sc.State {
+					applyNightMode(client, sc, desired)
+				}
+			}
+		}
+	}()
+}
+
+// 🔒/🔓 حالت اپلائی کریں (لاک ملے اور بوٹ ایڈمن ہو تو)
+func applyNightMode(client *whatsmeow.Client, sc *NightModeSchedule, desired string) {
+	chat, err := types.ParseJID(sc.ChatID)
+	if err != nil {
+		return
+	}
+	// ریڈ لاک ڈاؤن کے دوران نہ کھولیں، State وہی رہے تاکہ بعد میں دوبارہ کوشش ہو
+	if desired == "open" && isRaidLockdown(sc.ChatID) {
+		return
+	}
+	lockKey := nightModeLockPrefix + sc.ChatID + ":" + desired
+	if ok, err := rdb.SetNX(ctx, lockKey, getCleanID(client.Store.ID.User), 2*time.Minute).Result(); err != nil || !ok {
+		return // کوئی اور بوٹ یہ کام کر رہا ہے
+	}
+	if !botIsGroupAdmin(client, chat) {
+		rdb.Del(ctx, lockKey) // کسی دوسرے ایڈمن بوٹ کو موقع دیں
+		return
+	}
+
+	if err := client.SetGroupAnnounce(context.Background(), chat, desired == "closed"); err != nil {
+		fmt.Printf("⚠️ [NIGHTMODE] %s failed for %s: %v\n", desired, sc.ChatID, err)
+		rdb.Del(ctx, lockKey)
+		return
+	}
+
+	// تازہ ترین شیڈول پر صرف حالت بدلیں (درمیان میں کمانڈ سے تبدیلی ضائع نہ ہو)
+	if latest := getNightMode(sc.ChatID); latest != nil {
+		sc = latest
+	}
+	sc.State = desired
+	saveNightMode(sc)
+
+	msg := sc.OpenMsg
+	if desired == "closed" {
+		msg = sc.CloseMsg
+	}
+	if msg == "" {
+		if desired == "closed" {
+			msg = fmt.Sprintf("╔════════════════╗\n║ 🌙 NIGHT MODE\n╠════════════════\n║ Group closed\n║ Opens at %s\n╚════════════════", sc.Open)
+		} else {
+			msg = fmt.Sprintf("╔════════════════╗\n║ ☀️ GOOD MORNING\n╠════════════════\n║ Group opened\n║ Closes at %s\n╚════════════════", sc.Close)
+		}
+	}
+	sendPlainText(client, chat, msg)
+	fmt.Printf("🌙 [NIGHTMODE] Group %s → %s by bot %s\n", sc.ChatID, desired, getCleanID(client.Store.ID.User))
+}
+
+// "mon,tue" / "weekdays" / "weekend" / "all"
+func parseWeekdays(arg string) ([]int, bool) {
+	switch strings.ToLower(arg) {
+	case "all", "daily", "everyday":
+		return nil, true
+	case "weekdays":
+		return []int{1, 2, 3, 4, 5}, true
+	case "weekend":
+		return []int{0, 6}, true
+	}
+	var out []int
+	for _, part := range strings.Split(strings.ToLower(arg), ",") {
+		part = strings.TrimSpace(part)
+		found := false
+		for i, name := range weekdayNames {
+			if strings.HasPrefix(part, name) && part != "" {
+				out = append(out, i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return out, len(out) > 0
+}
+
+func weekdaysText(days []int) string {
+	if len(days) == 0 {
+		return "Every day"
+	}
+	names := make([]string, 0, len(days))
+	for _, d := range days {
+		if d >= 0 && d < len(weekdayNames) {
+			names = append(names, strings.ToUpper(weekdayNames[d][:1])+weekdayNames[d][1:])
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// ==================== .nightmode ====================
+func handleNightMode(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "⚠️ Redis not connected, schedules can't be saved.")
+		return
+	}
+
+	chatID := v.Info.Chat.String()
+	sc := getNightMode(chatID)
+	if sc == nil {
+		sc = &NightModeSchedule{ChatID: chatID, Timezone: nightModeDefaultZone}
+	}
+
+	if len(args) == 0 {
+		status := "🔴 OFF"
+		if sc.Enabled {
+			status = "🟢 ON"
+		}
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🌙 NIGHT MODE
+╠════════════════╣
+║ Status: %s
+║ Close: %s | Open: %s
+║ Days: %s
+║ Zone: %s
+╠════════════════╣
+║ .nightmode 23:00 07:00 [zone]
+║ .nightmode off
+║ .nightmode days mon,tue | weekdays | all
+║ .nightmode tz Asia/Karachi
+║ .nightmode closemsg <text>
+║ .nightmode openmsg <text>
+╚════════════════╝`, status, orDash(sc.Close), orDash(sc.Open), weekdaysText(sc.Days), sc.Timezone))
+		return
+	}
+
+	sub := strings.ToLower(args[0])
+	rest := strings.TrimSpace(strings.Join(args[1:], " "))
+
+	switch sub {
+	case "off":
+		sc.Enabled = false
+		msg := "✅ Night mode disabled."
+		// رات میں بند کیا تو گروپ کھول دیں، ورنہ شیڈولر اسے ہمیشہ بند چھوڑ دے گا
+		if sc.State == "closed" {
+			if isRaidLockdown(chatID) {
+				sc.State = "open" // لاک ڈاؤن ختم ہونے پر اینٹی ریڈ کھول دے گا
+				msg += "\n🚨 Group stays locked until the raid lockdown ends."
+			} else if !botIsGroupAdmin(client, v.Info.Chat) {
+				msg += "\n⚠️ Group is still closed (I'm not admin), open it manually."
+			} else if err := client.SetGroupAnnounce(context.Background(), v.Info.Chat, false); err != nil {
+				msg += "\n⚠️ Could not reopen the group: " + err.Error()
+			} else {
+				sc.State = "open"
+				msg += "\n🔓 Group reopened."
+			}
+		}
+		saveNightMode(sc)
+		replyMessage(client, v, msg)
+		return
+
+	case "on":
+		if sc.Close == "" || sc.Open == "" {
+			replyMessage(client, v, "⚠️ Set times first: .nightmode 23:00 07:00")
+			return
+		}
+		sc.Enabled = true
+		if sc.State == "" {
+			sc.State = "open" // نیا شیڈول، گروپ ابھی کھلا ہے
+		}
+
+	case "days":
+		days, ok := parseWeekdays(rest)
+		if !ok {
+			replyMessage(client, v, "⚠️ Usage: .nightmode days mon,tue,fri | weekdays | weekend | all")
+			return
+		}
+		sc.Days = days
+
+	case "tz", "timezone":
+		if _, err := time.LoadLocation(rest); err != nil || rest == "" {
+			replyMessage(client, v, "⚠️ Unknown timezone. Example: Asia/Karachi")
+			return
+		}
+		sc.Timezone = rest
+
+	case "closemsg":
+		sc.CloseMsg = rest
+
+	case "openmsg":
+		sc.OpenMsg = rest
+
+	default:
+		// .nightmode 23:00 07:00 [zone]
+		if len(args) < 2 {
+			replyMessage(client, v, "⚠️ Usage: .nightmode 23:00 07:00")
+			return
+		}
+		_, _, ok1 := parseClock(args[0])
+		_, _, ok2 := parseClock(args[1])
+		if !ok1 || !ok2 || args[0] == args[1] {
+			replyMessage(client, v, "⚠️ Invalid time. Use 24h format like 23:00 07:00")
+			return
+		}
+		if len(args) > 2 {
+			if _, err := time.LoadLocation(args[2]); err != nil {
+				replyMessage(client, v, "⚠️ Unknown timezone. Example: Asia/Karachi")
+				return
+			}
+			sc.Timezone = args[2]
+		}
+		sc.Close, sc.Open = args[0], args[1]
+		sc.Enabled = true
+		// اصل حالت برقرار رہے، شیڈولر اگلے ٹک پر درست کر دے گا
+		if sc.State == "" {
+			sc.State = "open"
+		}
+		sc.SetBy = getCleanID(v.Info.Sender.User)
+	}
+
+	saveNightMode(sc)
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ ✅ NIGHT MODE SAVED
+╠════════════════╣
+║ Close: %s | Open: %s
+║ Days: %s
+║ Zone: %s
+║ Applied by any admin bot
+╚════════════════╝`, orDash(sc.Close), orDash(sc.Open), weekdaysText(sc.Days), sc.Timezone))
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
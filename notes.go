@@ -0,0 +1,359 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ==================== گروپ نوٹس ====================
+// .save <name> ٹیکسٹ یا ریپلائی کیا ہوا میسج (میڈیا سمیت)، #name یا .get <name> سے واپس
+// میڈیا کا اصل ریفرنس (پروٹو) محفوظ + چھوٹی فائل کی کاپی، پرانا ریفرنس ہو تو دوبارہ اپلوڈ
+
+const (
+	notesPrefix       = "notes:"       // notes:<chat> ہیش → Note JSON
+	notesMediaPrefix  = "notes:media:" // notes:media:<chat>:<name> → فائل کی کاپی
+	notesMaxPerGroup  = 100
+	notesMaxCopyBytes = 1 << 20            // بڑی فائلیں ریڈیس میں نہیں، صرف واٹس ایپ ریفرنس
+	notesRefreshAge   = 7 * 24 * time.Hour // اس سے پرانا CDN ریفرنس دوبارہ اپلوڈ
+)
+
+var noteNamePattern = regexp.MustCompile(`^[a-z0-9_\-]{1,32}$`)
+
+// Note ایک محفوظ نوٹ
+type Note struct {
+	Name      string    `json:"name"`
+	Text      string    `json:"text,omitempty"`
+	Message   []byte    `json:"message,omitempty"` // میڈیا میسج کا پروٹو
+	Media     string    `json:"media,omitempty"`   // image / video / audio / document / sticker
+	AdminOnly bool      `json:"admin_only"`
+	By        string    `json:"by"`
+	At        time.Time `json:"at"`
+	RefAt     time.Time `json:"ref_at,omitempty"` // میڈیا ریفرنس کب اپلوڈ ہوا
+}
+
+func getNote(chat, name string) *Note {
+	if rdb == nil {
+		return nil
+	}
+	data, err := rdb.HGet(ctx, notesPrefix+chat, name).Bytes()
+	if err != nil {
+		return nil
+	}
+	var n Note
+	if json.Unmarshal(data, &n) != nil {
+		return nil
+	}
+	return &n
+}
+
+func saveNote(chat string, n *Note) {
+	if data, err := json.Marshal(n); err == nil {
+		rdb.HSet(ctx, notesPrefix+chat, n.Name, data)
+	}
+}
+
+func listNotes(chat string) []*Note {
+	var notes []*Note
+	if rdb == nil {
+		return notes
+	}
+	raw, _ := rdb.HGetAll(ctx, notesPrefix+chat).Result()
+	for _, val := range raw {
+		var n Note
+		if json.Unmarshal([]byte(val), &n) == nil {
+			notes = append(notes, &n)
+		}
+	}
+	sort.Slice(notes, func(i, j int) bool { return notes[i].Name < notes[j].Name })
+	return notes
+}
+
+func deleteNote(chat, name string) bool {
+	n, _ := rdb.HDel(ctx, notesPrefix+chat, name).Result()
+	rdb.Del(ctx, notesMediaPrefix+chat+":"+name)
+	return n > 0
+}
+
+// 📎 میسج میں سے میڈیا حصہ (صرف وہی، کوٹ/کانٹیکسٹ کے بغیر)
+func noteMediaPart(m *waProto.Message) (*waProto.Message, whatsmeow.DownloadableMessage, whatsmeow.MediaType, string) {
+	switch {
+	case m.GetImageMessage() != nil:
+		img := proto.Clone(m.GetImageMessage()).(*waProto.ImageMessage)
+		img.ContextInfo = nil
+		return &waProto.Message{ImageMessage: img}, img, whatsmeow.MediaImage, "image"
+	case m.GetVideoMessage() != nil:
+		vid := proto.Clone(m.GetVideoMessage()).(*waProto.VideoMessage)
+		vid.ContextInfo = nil
+		return &waProto.Message{VideoMessage: vid}, vid, whatsmeow.MediaVideo, "video"
+	case m.GetAudioMessage() != nil:
+		aud := proto.Clone(m.GetAudioMessage()).(*waProto.AudioMessage)
+		aud.ContextInfo = nil
+		return &waProto.Message{AudioMessage: aud}, aud, whatsmeow.MediaAudio, "audio"
+	case m.GetDocumentMessage() != nil:
+		doc := proto.Clone(m.GetDocumentMessage()).(*waProto.DocumentMessage)
+		doc.ContextInfo = nil
+		return &waProto.Message{DocumentMessage: doc}, doc, whatsmeow.MediaDocument, "document"
+	case m.GetStickerMessage() != nil:
+		st := proto.Clone(m.GetStickerMessage()).(*waProto.StickerMessage)
+		st.ContextInfo = nil
+		return &waProto.Message{StickerMessage: st}, st, whatsmeow.MediaImage, "sticker"
+	}
+	return nil, nil, "", ""
+}
+
+// 📏 بڑی فائل ڈاؤن لوڈ ہی نہ ہو (سائز میسج میں پہلے سے لکھا ہوتا ہے)
+func noteFitsCopy(m whatsmeow.DownloadableMessage) bool {
+	fl, ok := m.(interface{ GetFileLength() uint64 })
+	return !ok || fl.GetFileLength() <= notesMaxCopyBytes
+}
+
+// 🔁 نئے اپلوڈ کا ریفرنس میسج میں لگائیں
+func applyNoteUpload(m *waProto.Message, up whatsmeow.UploadResponse) {
+	switch {
+	case m.ImageMessage != nil:
+		x := m.ImageMessage
+		x.URL, x.DirectPath, x.MediaKey, x.FileSHA256, x.FileEncSHA256, x.FileLength = proto.String(up.URL), proto.String(up.DirectPath), up.MediaKey, up.FileSHA256, up.FileEncSHA256, proto.Uint64(up.FileLength)
+	case m.VideoMessage != nil:
+		x := m.VideoMessage
+		x.URL, x.DirectPath, x.MediaKey, x.FileSHA256, x.FileEncSHA256, x.FileLength = proto.String(up.URL), proto.String(up.DirectPath), up.MediaKey, up.FileSHA256, up.FileEncSHA256, proto.Uint64(up.FileLength)
+	case m.AudioMessage != nil:
+		x := m.AudioMessage
+		x.URL, x.DirectPath, x.MediaKey, x.FileSHA256, x.FileEncSHA256, x.FileLength = proto.String(up.URL), proto.String(up.DirectPath), up.MediaKey, up.FileSHA256, up.FileEncSHA256, proto.Uint64(up.FileLength)
+	case m.DocumentMessage != nil:
+		x := m.DocumentMessage
+		x.URL, x.DirectPath, x.MediaKey, x.FileSHA256, x.FileEncSHA256, x.FileLength = proto.String(up.URL), proto.String(up.DirectPath), up.MediaKey, up.FileSHA256, up.FileEncSHA256, proto.Uint64(up.FileLength)
+	case m.StickerMessage != nil:
+		x := m.StickerMessage
+		x.URL, x.DirectPath, x.MediaKey, x.FileSHA256, x.FileEncSHA256, x.FileLength = proto.String(up.URL), proto.String(up.DirectPath), up.MediaKey, up.FileSHA256, up.FileEncSHA256, proto.Uint64(up.FileLength)
+	}
+}
+
+// 📤 نوٹ بھیجیں (پرانا میڈیا ریفرنس ہو اور کاپی موجود ہو تو پہلے تازہ اپلوڈ)
+func sendNote(client *whatsmeow.Client, v *events.Message, n *Note) {
+	if n.Media == "" {
+		replyMessage(client, v, n.Text)
+		return
+	}
+	var msg waProto.Message
+	if proto.Unmarshal(n.Message, &msg) != nil {
+		replyMessage(client, v, "❌ This note is damaged, please save it again.")
+		return
+	}
+
+	chat := v.Info.Chat.String()
+	if time.Since(n.RefAt) > notesRefreshAge {
+		if data, err := rdb.Get(ctx, notesMediaPrefix+chat+":"+n.Name).Bytes(); err == nil && len(data) > 0 {
+			_, _, mediaType, _ := noteMediaPart(&msg)
+			if up, err := client.Upload(context.Background(), data, mediaType); err == nil {
+				applyNoteUpload(&msg, up)
+				if raw, err := proto.Marshal(&msg); err == nil {
+					n.Message, n.RefAt = raw, time.Now()
+					saveNote(chat, n)
+				}
+			}
+		}
+	}
+	if _, err := client.SendMessage(context.Background(), v.Info.Chat, &msg); err != nil {
+		replyMessage(client, v, "❌ Could not send this note's media.")
+	}
+}
+
+// 🔎 #name یا .get name — اجازت چیک کر کے بھیجیں
+func recallNote(client *whatsmeow.Client, v *events.Message, name string) bool {
+	n := getNote(v.Info.Chat.String(), strings.ToLower(name))
+	if n == nil {
+		return false
+	}
+	if n.AdminOnly && !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "🔒 This note is for Group Admins only.")
+		return true
+	}
+	sendNote(client, v, n)
+	return true
+}
+
+// #name (processMessage سے، سیکیورٹی چیکس سے پہلے) — کئی بوٹس ہوں تو ایک ہی جواب دے
+func handleNoteHashtag(client *whatsmeow.Client, v *events.Message, body string) bool {
+	name := strings.ToLower(strings.TrimPrefix(body, "#"))
+	if rdb == nil || !noteNamePattern.MatchString(name) || getNote(v.Info.Chat.String(), name) == nil {
+		return false
+	}
+	if ok, _ := rdb.SetNX(ctx, notesPrefix+"sent:"+v.Info.ID, 1, time.Minute).Result(); !ok {
+		return true
+	}
+	return recallNote(client, v, name)
+}
+
+// ==================== .save [admin] <name> [text] ====================
+func handleSaveNote(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "⚠️ Redis not connected.")
+		return
+	}
+
+	adminOnly, skip := false, 2
+	if len(args) > 0 && (strings.EqualFold(args[0], "admin") || args[0] == "-a") {
+		adminOnly, skip = true, 3
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		replyMessage(client, v, `╔════════════════╗
+║ 📝 NOTES
+╠════════════════╣
+║ .save <name> <text>
+║ .save <name> (reply to a message/media)
+║ .save admin <name> ... (admins only)
+║ #name or .get <name>
+║ .notes
+║ .clear <name>
+╚════════════════╝`)
+		return
+	}
+
+	name := strings.ToLower(args[0])
+	if !noteNamePattern.MatchString(name) {
+		replyMessage(client, v, "⚠️ Note names: a-z, 0-9, _ or - (max 32).")
+		return
+	}
+	chat := v.Info.Chat.String()
+	if getNote(chat, name) == nil && len(listNotes(chat)) >= notesMaxPerGroup {
+		replyMessage(client, v, fmt.Sprintf("⚠️ Maximum %d notes per group. Use .clear <name> first.", notesMaxPerGroup))
+		return
+	}
+
+	n := &Note{Name: name, AdminOnly: adminOnly, By: getCleanID(v.Info.Sender.User), At: time.Now()}
+	text := greetingArgText(v, skip)
+
+	quoted := v.Message.GetExtendedTextMessage().GetContextInfo().GetQuotedMessage()
+	if media, downloadable, mediaType, kind := noteMediaPart(quoted); media != nil {
+		if text != "" {
+			switch {
+			case media.ImageMessage != nil:
+				media.ImageMessage.Caption = proto.String(text)
+			case media.VideoMessage != nil:
+				media.VideoMessage.Caption = proto.String(text)
+			case media.DocumentMessage != nil:
+				media.DocumentMessage.Caption = proto.String(text)
+			}
+		}
+		// 💾 چھوٹی فائل کی کاپی + تازہ اپلوڈ، تاکہ ریفرنس ایکسپائر ہو تو دوبارہ اپلوڈ ہو سکے
+		rdb.Del(ctx, notesMediaPrefix+chat+":"+name)
+		if noteFitsCopy(downloadable) {
+			if data, err := client.Download(context.Background(), downloadable); err == nil && len(data) <= notesMaxCopyBytes {
+				rdb.Set(ctx, notesMediaPrefix+chat+":"+name, data, 0)
+				if up, err := client.Upload(context.Background(), data, mediaType); err == nil {
+					applyNoteUpload(media, up)
+				}
+			}
+		}
+		raw, err := proto.Marshal(media)
+		if err != nil {
+			replyMessage(client, v, "❌ Could not save this message.")
+			return
+		}
+		n.Message, n.Media, n.RefAt = raw, kind, time.Now()
+	} else {
+		if text == "" && quoted != nil {
+			text = getText(quoted)
+		}
+		if text == "" {
+			replyMessage(client, v, "⚠️ Give the note text, or reply to a message or media.")
+			return
+		}
+		n.Text = text
+		rdb.Del(ctx, notesMediaPrefix+chat+":"+name)
+	}
+
+	saveNote(chat, n)
+	lock := ""
+	if adminOnly {
+		lock = " 🔒"
+	}
+	replyMessage(client, v, fmt.Sprintf("✅ Note saved: #%s%s", name, lock))
+}
+
+// ==================== .get <name> ====================
+func handleGetNote(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if len(args) == 0 {
+		replyMessage(client, v, "⚠️ Usage: .get <name>")
+		return
+	}
+	if !recallNote(client, v, args[0]) {
+		replyMessage(client, v, "❌ No note named #"+strings.ToLower(args[0])+". See .notes")
+	}
+}
+
+// ==================== .notes ====================
+func handleListNotes(client *whatsmeow.Client, v *events.Message) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	notes := listNotes(v.Info.Chat.String())
+	if len(notes) == 0 {
+		replyMessage(client, v, "📝 No notes saved in this group.\n👮 Admins: .save <name> <text>")
+		return
+	}
+	icons := map[string]string{"image": "🖼️", "video": "🎬", "audio": "🎵", "document": "📄", "sticker": "🏷️"}
+	out := fmt.Sprintf("╔════════════════╗\n║ 📝 NOTES (%d)\n╠════════════════╣\n", len(notes))
+	for _, n := range notes {
+		line := "║ #" + n.Name
+		if icon := icons[n.Media]; icon != "" {
+			line += " " + icon
+		}
+		if n.AdminOnly {
+			line += " 🔒"
+		}
+		out += line + "\n"
+	}
+	out += "╠════════════════╣\n║ #name or .get <name>\n╚════════════════╝"
+	replyMessage(client, v, out)
+}
+
+// ==================== .clear <name> ====================
+func handleClearNote(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "⚠️ Redis not connected.")
+		return
+	}
+	if len(args) == 0 {
+		replyMessage(client, v, "⚠️ Usage: .clear <name>")
+		return
+	}
+	name := strings.ToLower(strings.TrimPrefix(args[0], "#"))
+	if !deleteNote(v.Info.Chat.String(), name) {
+		replyMessage(client, v, "❌ No note named #"+name)
+		return
+	}
+	replyMessage(client, v, "🗑️ Note #"+name+" deleted.")
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ==================== پارٹیسپنٹ کیشے ====================
+// ہر بوٹ + گروپ کی ممبر/ایڈمن لسٹ RAM میں، GroupInfo ایونٹس (جوائن، لیو، پروموٹ، ڈیموٹ) سے اپڈیٹ
+// isAdmin، ٹیگ کمانڈز اور سٹیٹس یہیں سے پڑھتے ہیں، وقفے وقفے سے سرور سے ری کنسائل
+
+const (
+	rosterReconcileEvery = 30 * time.Minute
+	rosterMaxAge         = 6 * time.Hour // اس سے پرانی لسٹ دوبارہ فیچ
+	rosterFetchTimeout   = 10 * time.Second
+)
+
+// RosterMember ایک ممبر
+type RosterMember struct {
+	JID          types.JID // میسج/مینشن کے لیے
+	Phone        string
+	LID          string
+	IsAdmin      bool
+	IsSuperAdmin bool
+}
+
+// GroupRoster ایک گروپ کی لسٹ
+type GroupRoster struct {
+	Name      string
+	Members   map[string]*RosterMember // صاف آئی ڈی → ممبر
+	Aliases   map[string]string        // نمبر/LID → Members کی کی
+	FetchedAt time.Time
+	Stale     bool // نامعلوم تبدیلی آئی، اگلی بار ری فیچ
+}
+
+var (
+	rosterCache = make(map[string]*GroupRoster) // botID|chatID
+	rosterMu    sync.RWMutex
+)
+
+func rosterKey(client *whatsmeow.Client, chat types.JID) string {
+	botID := ""
+	if client.Store.ID != nil {
+		botID = getCleanID(client.Store.ID.User)
+	}
+	return botID + "|" + chat.String()
+}
+
+func (r *GroupRoster) addMember(m *RosterMember) {
+	key := getCleanID(m.JID.User)
+	r.Members[key] = m
+	r.Aliases[key] = key
+	if m.Phone != "" {
+		r.Aliases[m.Phone] = key
+	}
+	if m.LID != "" {
+		r.Aliases[m.LID] = key
+	}
+}
+
+func (r *GroupRoster) find(id string) *RosterMember {
+	if key, ok := r.Aliases[id]; ok {
+		return r.Members[key]
+	}
+	return nil
+}
+
+func (r *GroupRoster) removeMember(id string) {
+	m := r.find(id)
+	if m == nil {
+		return
+	}
+	key := getCleanID(m.JID.User)
+	delete(r.Members, key)
+	for alias, k := range r.Aliases {
+		if k == key {
+			delete(r.Aliases, alias)
+		}
+	}
+}
+
+// 🔄 سرور سے تازہ لسٹ
+func refreshGroupRoster(client *whatsmeow.Client, chat types.JID) (*GroupRoster, error) {
+	c, cancel := context.WithTimeout(context.Background(), rosterFetchTimeout)
+	defer cancel()
+	info, err := client.GetGroupInfo(c, chat)
+	if err != nil {
+		return nil, err
+	}
+	r := &GroupRoster{
+		Name:      info.Name,
+		Members:   make(map[string]*RosterMember, len(info.Participants)),
+		Aliases:   make(map[string]string, len(info.Participants)*2),
+		FetchedAt: time.Now(),
+	}
+	for _, p := range info.Participants {
+		r.addMember(&RosterMember{
+			JID:          p.JID,
+			Phone:        getCleanID(p.PhoneNumber.User),
+			LID:          getCleanID(p.LID.User),
+			IsAdmin:      p.IsAdmin || p.IsSuperAdmin,
+			IsSuperAdmin: p.IsSuperAdmin,
+		})
+	}
+	rosterMu.Lock()
+	rosterCache[rosterKey(client, chat)] = r
+	rosterMu.Unlock()
+	return r, nil
+}
+
+// 📋 کیشے سے لسٹ (نہ ہو، پرانی یا سٹیل ہو تو فیچ)
+func getGroupRoster(client *whatsmeow.Client, chat types.JID) (*GroupRoster, error) {
+	rosterMu.RLock()
+	r, ok := rosterCache[rosterKey(client, chat)]
+	fresh := ok && !r.Stale && time.Since(r.FetchedAt) < rosterMaxAge
+	rosterMu.RUnlock()
+	if fresh {
+		return r, nil
+	}
+	return refreshGroupRoster(client, chat)
+}
+
+// 👥 ممبرز کی کاپی (ٹیگ کمانڈز کے لیے)
+func groupMembers(client *whatsmeow.Client, chat types.JID) []RosterMember {
+	r, err := getGroupRoster(client, chat)
+	if err != nil {
+		return nil
+	}
+	rosterMu.RLock()
+	defer rosterMu.RUnlock()
+	out := make([]RosterMember, 0, len(r.Members))
+	for _, m := range r.Members {
+		out = append(out, *m)
+	}
+	return out
+}
+
+// 👮 کیا یوزر ایڈمن ہے؟
+func rosterIsAdmin(client *whatsmeow.Client, chat, user types.JID) bool {
+	r, err := getGroupRoster(client, chat)
+	if err != nil {
+		fmt.Println("⚠️ Admin check timed out or failed:", err)
+		return false // اگر فیل ہو جائے تو سیفٹی کے لیے false
+	}
+	rosterMu.RLock()
+	defer rosterMu.RUnlock()
+	m := r.find(getCleanID(user.User))
+	return m != nil && m.IsAdmin
+}
+
+// ⚡ GroupInfo ایونٹ سے لسٹ اپڈیٹ (handleGroupEvents سے، باقی ہینڈلرز سے پہلے)
+func updateRosterFromEvent(client *whatsmeow.Client, v *events.GroupInfo) {
+	key := rosterKey(client, v.JID)
+	rosterMu.Lock()
+	defer rosterMu.Unlock()
+	r, ok := rosterCache[key]
+	if !ok {
+		return // کیشے میں نہیں، اگلی بار فیچ ہو گی
+	}
+
+	for _, u := range v.Join {
+		if r.find(getCleanID(u.User)) == nil {
+			m := &RosterMember{JID: u}
+			if u.Server == types.HiddenUserServer {
+				m.LID = getCleanID(u.User)
+			} else {
+				m.Phone = getCleanID(u.User)
+			}
+			r.addMember(m)
+		}
+	}
+	for _, u := range v.Leave {
+		// بوٹ خود نکل گیا تو پوری لسٹ ختم
+		if client.Store.ID != nil && (u.User == client.Store.ID.User || u.User == client.Store.LID.User) {
+			delete(rosterCache, key)
+			return
+		}
+		r.removeMember(getCleanID(u.User))
+	}
+	for _, u := range v.Promote {
+		if m := r.find(getCleanID(u.User)); m != nil {
+			m.IsAdmin = true
+		} else {
+			r.Stale = true
+		}
+	}
+	for _, u := range v.Demote {
+		if m := r.find(getCleanID(u.User)); m != nil {
+			m.IsAdmin = false
+			m.IsSuperAdmin = false
+		} else {
+			r.Stale = true
+		}
+	}
+	if v.Name != nil {
+		r.Name = v.Name.Name
+	}
+	if len(v.UnknownChanges) > 0 {
+		r.Stale = true
+	}
+}
+
+// 📊 سٹیٹس کے لیے گنتی
+func rosterStats() (groups, members int) {
+	rosterMu.RLock()
+	defer rosterMu.RUnlock()
+	for _, r := range rosterCache {
+		groups++
+		members += len(r.Members)
+	}
+	return
+}
+
+// ⏰ وقفے وقفے سے ری کنسائل (ConnectNewSession سے، ہر بوٹ کے لیے)
+func StartRosterReconciler(client *whatsmeow.Client) {
+	go func() {
+		for {
+			time.Sleep(rosterReconcileEvery)
+			if !isClientActive(client) {
+				return
+			}
+			if !client.IsConnected() {
+				continue
+			}
+			prefix := getCleanID(client.Store.ID.User) + "|"
+			var chats []types.JID
+			rosterMu.RLock()
+			for key := range rosterCache {
+				if strings.HasPrefix(key, prefix) {
+					if jid, err := types.ParseJID(strings.TrimPrefix(key, prefix)); err == nil {
+						chats = append(chats, jid)
+					}
+				}
+			}
+			rosterMu.RUnlock()
+			for _, chat := range chats {
+				if _, err := refreshGroupRoster(client, chat); err != nil {
+					// گروپ سے نکل گئے یا ختم، کیشے سے ہٹا دیں
+					rosterMu.Lock()
+					delete(rosterCache, prefix+chat.String())
+					rosterMu.Unlock()
+				}
+				time.Sleep(2 * time.Second)
+			}
+		}
+	}()
+}
+
+// ==================== .refreshgroup ====================
+func handleRefreshGroup(client *whatsmeow.Client, v *events.Message) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+	r, err := refreshGroupRoster(client, v.Info.Chat)
+	if err != nil {
+		replyMessage(client, v, "❌ Refresh failed: "+err.Error())
+		return
+	}
+	rosterMu.RLock()
+	admins, total := 0, len(r.Members)
+	for _, m := range r.Members {
+		if m.IsAdmin {
+			admins++
+		}
+	}
+	rosterMu.RUnlock()
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🔄 GROUP REFRESHED
+╠════════════════╣
+║ 👥 Members: %d
+║ 👮 Admins: %d
+║ 🕒 %s
+╚════════════════╝`, total, admins, r.FetchedAt.Format("15:04:05")))
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ==================== واٹس ایپ پولز ====================
+// .poll "سوال" | آپشن 1 | آپشن 2 → اصلی پول، ووٹس ڈیکرپٹ کر کے ریڈیس میں
+// ووٹ صرف وہی بوٹ پڑھ سکتا ہے جس نے پول بھیجا (میسج سیکرٹ اسی کے سٹور میں ہے)
+
+const (
+	pollPrefix     = "poll:"
+	pollTTL        = 30 * 24 * time.Hour
+	pollMaxOptions = 12
+)
+
+// Poll ایک بھیجا ہوا پول
+type Poll struct {
+	ID        string    `json:"id"`
+	BotID     string    `json:"bot_id"`
+	Chat      string    `json:"chat"`
+	Question  string    `json:"question"`
+	Options   []string  `json:"options"`
+	Multi     bool      `json:"multi"`
+	CreatedBy string    `json:"created_by"`
+	Closed    bool      `json:"closed"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func getPoll(id string) *Poll {
+	if rdb == nil || id == "" {
+		return nil
+	}
+	data, err := rdb.Get(ctx, pollPrefix+id).Bytes()
+	if err != nil {
+		return nil
+	}
+	var p Poll
+	if json.Unmarshal(data, &p) != nil {
+		return nil
+	}
+	return &p
+}
+
+func savePoll(p *Poll) {
+	if data, err := json.Marshal(p); err == nil {
+		rdb.Set(ctx, pollPrefix+p.ID, data, pollTTL)
+	}
+}
+
+// ووٹر → منتخب آپشنز (انڈیکس)
+func pollVotes(id string) map[string][]int {
+	votes := make(map[string][]int)
+	raw, _ := rdb.HGetAll(ctx, pollPrefix+"votes:"+id).Result()
+	for voter, val := range raw {
+		var idx []int
+		if json.Unmarshal([]byte(val), &idx) == nil && len(idx) > 0 {
+			votes[voter] = idx
+		}
+	}
+	return votes
+}
+
+// "سوال" | a | b (آگے -m / multi = کئی آپشنز)
+func parsePollArgs(text string) (question string, options []string, multi bool, err error) {
+	text = strings.TrimSpace(text)
+	for _, flag := range []string{"--multi", "-m", "multi"} {
+		if strings.HasPrefix(strings.ToLower(text), flag+" ") {
+			multi = true
+			text = strings.TrimSpace(text[len(flag):])
+			break
+		}
+	}
+	parts := strings.Split(text, "|")
+	question = strings.Trim(strings.TrimSpace(parts[0]), `"“”`)
+	seen := make(map[string]bool)
+	for _, o := range parts[1:] {
+		o = strings.TrimSpace(o)
+		if o == "" || seen[strings.ToLower(o)] {
+			continue
+		}
+		seen[strings.ToLower(o)] = true
+		options = append(options, o)
+	}
+	switch {
+	case question == "":
+		err = fmt.Errorf("question is empty")
+	case len(options) < 2:
+		err = fmt.Errorf("at least 2 options")
+	case len(options) > pollMaxOptions:
+		err = fmt.Errorf("maximum %d options", pollMaxOptions)
+	}
+	return
+}
+
+// 🗳️ آنے والا ووٹ (processMessage سے، گارڈز سے پہلے)
+func recordPollVote(client *whatsmeow.Client, v *events.Message) {
+	update := v.Message.GetPollUpdateMessage()
+	if rdb == nil || update == nil {
+		return
+	}
+	p := getPoll(update.GetPollCreationMessageKey().GetID())
+	if p == nil || p.Closed || p.BotID != getCleanID(client.Store.ID.User) {
+		return
+	}
+	vote, err := client.DecryptPollVote(context.Background(), v)
+	if err != nil {
+		fmt.Printf("⚠️ [POLL] vote decrypt failed: %v\n", err)
+		return
+	}
+
+	hashes := whatsmeow.HashPollOptions(p.Options)
+	var selected []int
+	for _, h := range vote.GetSelectedOptions() {
+		for i, oh := range hashes {
+			if bytes.Equal(h, oh) {
+				selected = append(selected, i)
+			}
+		}
+	}
+
+	// ووٹ واپس لینے پر خالی لسٹ آتی ہے
+	key := pollPrefix + "votes:" + p.ID
+	voter := activityUserID(client, v.Info.Sender)
+	if len(selected) == 0 {
+		rdb.HDel(ctx, key, voter)
+		return
+	}
+	data, _ := json.Marshal(selected)
+	rdb.HSet(ctx, key, voter, data)
+	rdb.Expire(ctx, key, pollTTL)
+}
+
+// 📊 نتائج کا کارڈ
+func pollResultsCard(client *whatsmeow.Client, p *Poll) string {
+	votes := pollVotes(p.ID)
+	counts := make([]int, len(p.Options))
+	voters := make([][]string, len(p.Options))
+	for voter, idx := range votes {
+		name := displayName(client, types.NewJID(voter, types.DefaultUserServer))
+		for _, i := range idx {
+			if i >= 0 && i < len(counts) {
+				counts[i]++
+				voters[i] = append(voters[i], name)
+			}
+		}
+	}
+
+	title := "📊 POLL RESULTS"
+	if p.Closed {
+		title = "🔒 POLL CLOSED"
+	}
+	mode := "Single choice"
+	if p.Multi {
+		mode = "Multiple choice"
+	}
+	out := fmt.Sprintf("╔════════════════╗\n║ %s\n╠════════════════╣\n║ ❓ %s\n║ ☑️ %s\n╠════════════════╣\n", title, p.Question, mode)
+
+	best := 0
+	for _, c := range counts {
+		best = max(best, c)
+	}
+	for i, opt := range p.Options {
+		pct := 0
+		if len(votes) > 0 {
+			pct = counts[i] * 100 / len(votes)
+		}
+		bar := strings.Repeat("▓", pct/10) + strings.Repeat("░", 10-pct/10)
+		mark := ""
+		if p.Closed && best > 0 && counts[i] == best {
+			mark = " 🏆"
+		}
+		out += fmt.Sprintf("║ %d. %s%s\n║    %s %d (%d%%)\n", i+1, opt, mark, bar, counts[i], pct)
+		if len(voters[i]) > 0 {
+			out += "║    👤 " + strings.Join(voters[i], ", ") + "\n"
+		}
+	}
+	out += fmt.Sprintf("╠════════════════╣\n║ 🗳️ Voters: %d\n╚════════════════╝", len(votes))
+	return out
+}
+
+// ریپلائی کیا ہوا پول، ورنہ اس چیٹ کا آخری پول
+func targetPoll(v *events.Message) *Poll {
+	if quotedID := v.Message.GetExtendedTextMessage().GetContextInfo().GetStanzaID(); quotedID != "" {
+		return getPoll(quotedID)
+	}
+	id, _ := rdb.Get(ctx, pollPrefix+"last:"+v.Info.Chat.String()).Result()
+	return getPoll(id)
+}
+
+// ==================== .poll ====================
+func handlePoll(client *whatsmeow.Client, v *events.Message, args []string) {
+	if rdb == nil {
+		replyMessage(client, v, "⚠️ Redis not connected.")
+		return
+	}
+	if len(args) > 0 && strings.EqualFold(args[0], "close") {
+		handlePollClose(client, v)
+		return
+	}
+
+	question, options, multi, err := parsePollArgs(greetingArgText(v, 1))
+	if err != nil {
+		replyMessage(client, v, `╔════════════════╗
+║ 📊 POLL
+╠════════════════╣
+║ ⚠️ `+err.Error()+`
+╠════════════════╣
+║ .poll "Question" | a | b
+║ .poll -m "Question" | a | b | c
+║   (-m = multiple choice)
+║ .pollresult (reply to poll)
+║ .pollclose (reply to poll)
+╚════════════════╝`)
+		return
+	}
+
+	selectable := 1
+	if multi {
+		selectable = 0 // 0 = جتنے چاہیں
+	}
+	resp, err := client.SendMessage(context.Background(), v.Info.Chat, client.BuildPollCreation(question, options, selectable))
+	if err != nil {
+		replyMessage(client, v, "❌ Could not send the poll.")
+		return
+	}
+
+	savePoll(&Poll{
+		ID:        resp.ID,
+		BotID:     getCleanID(client.Store.ID.User),
+		Chat:      v.Info.Chat.String(),
+		Question:  question,
+		Options:   options,
+		Multi:     multi,
+		CreatedBy: getCleanID(v.Info.Sender.User),
+		CreatedAt: time.Now(),
+	})
+	rdb.Set(ctx, pollPrefix+"last:"+v.Info.Chat.String(), resp.ID, pollTTL)
+}
+
+// ==================== .pollresult ====================
+func handlePollResult(client *whatsmeow.Client, v *events.Message) {
+	if rdb == nil {
+		replyMessage(client, v, "⚠️ Redis not connected.")
+		return
+	}
+	p := targetPoll(v)
+	if p == nil {
+		replyMessage(client, v, "⚠️ Reply to a poll created by this bot.")
+		return
+	}
+	replyMessage(client, v, pollResultsCard(client, p))
+}
+
+// ==================== .pollclose ====================
+func handlePollClose(client *whatsmeow.Client, v *events.Message) {
+	if rdb == nil {
+		replyMessage(client, v, "⚠️ Redis not connected.")
+		return
+	}
+	p := targetPoll(v)
+	if p == nil {
+		replyMessage(client, v, "⚠️ Reply to a poll created by this bot.")
+		return
+	}
+	sender := getCleanID(v.Info.Sender.User)
+	if sender != p.CreatedBy && !isOwner(client, v.Info.Sender) && !(v.Info.IsGroup && isAdmin(client, v.Info.Chat, v.Info.Sender)) {
+		replyMessage(client, v, "👮 Only the poll creator or Group Admins can close this poll.")
+		return
+	}
+	if p.Closed {
+		replyMessage(client, v, "🔒 This poll is already closed.\n\n"+pollResultsCard(client, p))
+		return
+	}
+	p.Closed = true
+	savePoll(p)
+	replyMessage(client, v, pollResultsCard(client, p))
+}
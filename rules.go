@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ==================== گروپ رولز ====================
+// ہر لائن ایک رول، نمبر خودبخود لگتے ہیں تاکہ .warn @user rule 3 حوالہ دے سکے
+// نئے ممبرز کو رولز DM میں یا ویلکم کے بعد گروپ میں
+
+const maxGroupRules = 30
+
+var ruleNumberPrefix = regexp.MustCompile(`^\s*(\d+[\.\)\-:]|[•\-\*])\s*`)
+
+// ٹیکسٹ → رولز کی لسٹ (پہلے سے لگے نمبر/بلٹس ہٹا کر)
+func parseRulesText(text string) []string {
+	var rules []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(ruleNumberPrefix.ReplaceAllString(line, ""))
+		if line != "" {
+			rules = append(rules, line)
+		}
+	}
+	return rules
+}
+
+// 📜 نمبر وار رولز ({rules} اور .rules کے لیے)
+func groupRulesText(s *GroupSettings) string {
+	var sb strings.Builder
+	for i, r := range s.Rules {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "%d. %s", i+1, r)
+	}
+	return sb.String()
+}
+
+// رول نمبر n (1 سے شروع)
+func groupRule(s *GroupSettings, n int) (string, bool) {
+	if n < 1 || n > len(s.Rules) {
+		return "", false
+	}
+	return s.Rules[n-1], true
+}
+
+func rulesCard(s *GroupSettings, groupName string) string {
+	title := "📜 GROUP RULES"
+	if groupName != "" {
+		title += "\n║ 🏷️ " + groupName
+	}
+	return fmt.Sprintf("╔════════════════╗\n║ %s\n╠════════════════╣\n%s\n╚════════════════╝", title, groupRulesText(s))
+}
+
+func rulesOnJoinText(mode string) string {
+	switch mode {
+	case "dm":
+		return "🟢 DM"
+	case "group":
+		return "🟢 In Group"
+	}
+	return onOffText(false)
+}
+
+// ⚡ نئے ممبرز کو رولز (handleGroupInfoChange، ویلکم کے بعد)
+func sendRulesOnJoin(client *whatsmeow.Client, s *GroupSettings, v *events.GroupInfo) {
+	if len(s.Rules) == 0 || len(v.Join) == 0 {
+		return
+	}
+	groupName := ""
+	if info, err := client.GetGroupInfo(context.Background(), v.JID); err == nil {
+		groupName = info.Name
+	}
+	card := rulesCard(s, groupName)
+
+	switch s.RulesOnJoin {
+	case "dm":
+		for _, u := range v.Join {
+			sendPlainText(client, u.ToNonAD(), card+"\n\n📌 Please follow these rules in the group.")
+			time.Sleep(500 * time.Millisecond)
+		}
+	case "group":
+		var mentions []string
+		var tags []string
+		for _, u := range v.Join {
+			mentions = append(mentions, u.String())
+			tags = append(tags, "@"+u.User)
+		}
+		client.SendMessage(context.Background(), v.JID, &waProto.Message{
+			ExtendedTextMessage: &waProto.ExtendedTextMessage{
+				Text:        proto.String(strings.Join(tags, " ") + "\n" + card),
+				ContextInfo: &waProto.ContextInfo{MentionedJID: mentions},
+			},
+		})
+	}
+}
+
+// ==================== .rules ====================
+func handleRules(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	// 📖 کوئی بھی رولز دیکھ سکتا ہے
+	if sub == "" {
+		if len(s.Rules) == 0 {
+			replyMessage(client, v, "📜 No rules set for this group yet.\n👮 Admins: .rules set <one rule per line>")
+			return
+		}
+		groupName := ""
+		if info, err := client.GetGroupInfo(context.Background(), v.Info.Chat); err == nil {
+			groupName = info.Name
+		}
+		replyMessage(client, v, rulesCard(s, groupName))
+		return
+	}
+
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+
+	switch sub {
+	case "set", "add":
+		rules := parseRulesText(greetingArgText(v, 2))
+		if len(rules) == 0 {
+			replyMessage(client, v, "⚠️ Usage: .rules set <rule 1>\n<rule 2>\n...")
+			return
+		}
+		if sub == "add" {
+			rules = append(append([]string{}, s.Rules...), rules...)
+		}
+		if len(rules) > maxGroupRules {
+			replyMessage(client, v, fmt.Sprintf("⚠️ Maximum %d rules.", maxGroupRules))
+			return
+		}
+		s.Rules = rules
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("✅ %d rules saved.\n\n%s", len(s.Rules), groupRulesText(s)))
+
+	case "del", "remove":
+		n := 0
+		if len(args) > 1 {
+			n, _ = strconv.Atoi(args[1])
+		}
+		if _, ok := groupRule(s, n); !ok {
+			replyMessage(client, v, fmt.Sprintf("⚠️ Usage: .rules del <1-%d>", len(s.Rules)))
+			return
+		}
+		s.Rules = append(s.Rules[:n-1:n-1], s.Rules[n:]...)
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("🗑️ Rule %d removed. Remaining rules were renumbered.", n))
+
+	case "clear":
+		s.Rules = nil
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "🗑️ All rules cleared.")
+
+	case "onjoin":
+		mode := ""
+		if len(args) > 1 {
+			mode = strings.ToLower(args[1])
+		}
+		switch mode {
+		case "dm", "group":
+			s.RulesOnJoin = mode
+		case "off":
+			s.RulesOnJoin = ""
+		default:
+			replyMessage(client, v, "⚠️ Usage: .rules onjoin dm / group / off")
+			return
+		}
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "📨 Rules for newcomers: "+rulesOnJoinText(s.RulesOnJoin))
+
+	default:
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 📜 RULES SETUP
+╠════════════════╣
+║ Rules: %d
+║ On Join: %s
+╠════════════════╣
+║ .rules
+║ .rules set <one per line>
+║ .rules add <rule>
+║ .rules del <n>
+║ .rules clear
+║ .rules onjoin dm/group/off
+║ .warn @user rule <n>
+╚════════════════╝`, len(s.Rules), rulesOnJoinText(s.RulesOnJoin)))
+	}
+}
+
+// ==================== .warn @user [rule <n> | reason] ====================
+func handleWarn(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+
+	target, rest := extractTargetJID(v, args)
+	if target.IsEmpty() {
+		replyMessage(client, v, "⚠️ Usage: .warn @user [rule <n> | reason] (or reply to a message)")
+		return
+	}
+	if isAdmin(client, v.Info.Chat, target) || isOwner(client, target) {
+		replyMessage(client, v, "❌ Admins can't be warned.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	rule, reason := "manual", strings.TrimSpace(strings.Join(rest, " "))
+	if len(rest) >= 2 && strings.EqualFold(rest[0], "rule") {
+		n, err := strconv.Atoi(strings.TrimPrefix(rest[1], "#"))
+		text, ok := groupRule(s, n)
+		if err != nil || !ok {
+			replyMessage(client, v, fmt.Sprintf("⚠️ Rule %s not found. This group has %d rules (.rules).", rest[1], len(s.Rules)))
+			return
+		}
+		rule = fmt.Sprintf("rule %d", n)
+		reason = fmt.Sprintf("Rule %d: %s", n, text)
+		if extra := strings.TrimSpace(strings.Join(rest[2:], " ")); extra != "" {
+			reason += " (" + extra + ")"
+		}
+	}
+	if reason == "" {
+		reason = "No reason given"
+	}
+
+	result := addWarning(client, botID, v.Info.Chat, target, getCleanID(v.Info.Sender.User), rule, reason)
+	msg := fmt.Sprintf(`╔════════════════╗
+║ ⚠️ WARNING
+╠════════════════╣
+║ 👤 User: @%s
+║ 📜 %s
+║ 📊 %s
+╚════════════════╝`, target.User, reason, result)
+	client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text:        proto.String(msg),
+			ContextInfo: &waProto.ContextInfo{MentionedJID: []string{target.String()}},
+		},
+	})
+}
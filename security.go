@@ -3,8 +3,8 @@ package main
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
-	"time"
 	"encoding/json"
     //"unicode"
 	"go.mau.fi/whatsmeow"
@@ -15,8 +15,6 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
-var AntiBugEnabled = false
-
 // 🛡️ سیٹنگز کا ڈھانچہ (Structure)
 // اس میں تم مزید چیزیں بھی ڈال سکتے ہو جیسے AntiLink، Welcome وغیرہ
 type BotSettings struct {
@@ -71,57 +69,6 @@ func LoadAllSettings(rdb *redis.Client, botID string) BotSettings {
 	return settings
 }
 
-// 🛡️ گروپ سیکیورٹی سیٹنگز کا ڈھانچہ
-type GroupSecurity struct {
-	AntiLink   bool `json:"anti_link"`
-	AllowAdmin bool `json:"allow_admin"` // جو آپ اسٹیج 1 میں پوچھ رہے ہیں
-}
-
-// 💾 گروپ سیٹنگ سیو کرنا (Group Specific)
-func SaveGroupSecurity(rdb *redis.Client, botLID string, groupID string, data GroupSecurity) {
-	key := fmt.Sprintf("sec:%s:%s", botLID, groupID)
-	payload, _ := json.Marshal(data)
-	
-	err := rdb.Set(ctx, key, payload, 0).Err()
-	if err != nil {
-		fmt.Printf("❌ [REDIS] Save Error for Group %s: %v\n", groupID, err)
-	}
-}
-
-// 📥 گروپ سیٹنگ لوڈ کرنا (Group Specific)
-func LoadGroupSecurity(rdb *redis.Client, botLID string, groupID string) GroupSecurity {
-	key := fmt.Sprintf("sec:%s:%s", botLID, groupID)
-	val, err := rdb.Get(ctx, key).Result()
-	
-	var data GroupSecurity
-	if err != nil {
-		// اگر کوئی سیٹنگ نہیں ملی تو ڈیفالٹ (False) واپس کریں
-		return GroupSecurity{AntiLink: false, AllowAdmin: false}
-	}
-	
-	json.Unmarshal([]byte(val), &data)
-	return data
-}
-
-// فرض کریں یوزر نے 'antilink' آن کرنے کا فیصلہ کر لیا ہے
-func finalizeSecurity(client *whatsmeow.Client, senderLID string, choice string) {
-	state := setupMap[senderLID]
-	if state == nil { return }
-
-	allowAdmin := (choice == "1") // اگر یوزر نے 1 دبایا تو ایڈمن الاؤ ہیں
-	
-	// سیٹنگز تیار کریں
-	newConfig := GroupSecurity{
-		AntiLink:   true, // کیونکہ وہ اینٹی لنک کا سیٹ اپ کر رہا تھا
-		AllowAdmin: allowAdmin,
-	}
-
-	// 💾 ریڈیس میں اس گروپ کے لیے مخصوص سیو کریں
-	SaveGroupSecurity(rdb, state.BotLID, state.GroupID, newConfig)
-	
-	// میپ سے ڈیلیٹ کر دیں
-	delete(setupMap, senderLID)
-}
 // ==================== سیکورٹی سسٹم ====================
 func checkSecurity(client *whatsmeow.Client, v *events.Message) {
 	// ✅ 1. Bot ID نکالیں
@@ -142,25 +89,23 @@ func checkSecurity(client *whatsmeow.Client, v *events.Message) {
 	// ✅ Anti-link check
 	if s.Antilink && containsLink(getText(v.Message)) {
 		// نوٹ: takeSecurityAction کو بھی botID پاس کیا ہے تاکہ وہ Save کر سکے
-		takeSecurityAction(client, v, s, s.AntilinkAction, "Link detected", botID)
+		takeSecurityAction(client, v, s, "antilink", s.AntilinkAction, "Link detected", botID)
 		return
 	}
 
-	// Anti-picture check
-	if s.AntiPic && v.Message.ImageMessage != nil {
-		takeSecurityAction(client, v, s, "delete", "Image not allowed", botID)
+	// ✅ Anti-forward check (ٹیکسٹ، تصویر، ویڈیو، ڈاکیومنٹ)
+	if ok, reason := matchAntiForward(s, v); ok {
+		takeSecurityAction(client, v, s, "antiforward", s.AntiForward.Action, reason, botID)
 		return
 	}
 
-	// Anti-video check
-	if s.AntiVideo && v.Message.VideoMessage != nil {
-		takeSecurityAction(client, v, s, "delete", "Video not allowed", botID)
-		return
-	}
-
-	// Anti-sticker check
-	if s.AntiSticker && v.Message.StickerMessage != nil {
-		takeSecurityAction(client, v, s, "delete", "Sticker not allowed", botID)
+	// ✅ میڈیا رولز (تصویر، ویڈیو، اسٹیکر، ڈاکیومنٹ، وائس، پول ...)
+	if def, r := matchMediaRule(s, v); r != nil {
+		reason := r.Reason
+		if reason == "" {
+			reason = def.Reason
+		}
+		takeSecurityAction(client, v, s, def.Command, r.Action, reason, botID)
 		return
 	}
 }
@@ -189,13 +134,14 @@ func containsLink(text string) bool {
 
 // ✅ فنکشن میں botID کا اضافہ کیا گیا ہے
 // ✅ فنکشن میں botID کا اضافہ کیا گیا ہے
-func takeSecurityAction(client *whatsmeow.Client, v *events.Message, s *GroupSettings, action, reason string, botID string) {
+func takeSecurityAction(client *whatsmeow.Client, v *events.Message, s *GroupSettings, rule, action, reason string, botID string) {
 
 	// ===========================
 	// 1️⃣ ADMIN SAFETY CHECK (UPDATED: USES CACHE)
 	// ===========================
 	// یہاں ہم نے پرانا بھاری لوپ ہٹا کر آپ کا کیش والا فنکشن لگا دیا ہے
-	if s.AntilinkAdmin {
+	// ہر رول کی اپنی ایڈمن چھوٹ ہے (antilink → AntilinkAdmin، میڈیا → MediaRule.AdminBypass)
+	if securityRuleAdminBypass(s, rule) {
 		if isAdmin(client, v.Info.Chat, v.Info.Sender) {
 			return // ایڈمن ہے تو کچھ نہ کرو (Super Fast)
 		}
@@ -238,6 +184,7 @@ func takeSecurityAction(client *whatsmeow.Client, v *events.Message, s *GroupSet
 			replyMessage(client, v, "⚠️ Failed to Delete (Give me Admin Rights)")
 			return
 		}
+		logAutoModAction(botID, v, "delete", rule, reason)
 
 		// نوٹیفکیشن بھیجیں
 		msg := fmt.Sprintf(`╔════════════════╗
@@ -271,6 +218,8 @@ func takeSecurityAction(client *whatsmeow.Client, v *events.Message, s *GroupSet
 			replyMessage(client, v, "⚠️ Failed to Kick (Give me Admin Rights)")
 			return
 		}
+		logAutoModAction(botID, v, "kick", rule, reason)
+		go sendAppealHint(client, v.Info.Chat, v.Info.Sender, reason)
 		
 		msg := fmt.Sprintf(`╔════════════════╗
 ║ 👢 KICKED
@@ -306,6 +255,8 @@ func takeSecurityAction(client *whatsmeow.Client, v *events.Message, s *GroupSet
 				replyMessage(client, v, "⚠️ Failed to Kick (User has 3 warnings)")
 			} else {
 				delete(s.Warnings, senderKey)
+				logAutoModAction(botID, v, "kick", rule, reason+" (3/3 warnings)")
+				go sendAppealHint(client, v.Info.Chat, v.Info.Sender, reason+" (3/3 warnings)")
 				
 				msg := fmt.Sprintf(`╔════════════════╗
 ║ 🚫 KICKED
@@ -324,6 +275,7 @@ func takeSecurityAction(client *whatsmeow.Client, v *events.Message, s *GroupSet
 				})
 			}
 		} else {
+			logAutoModAction(botID, v, "warn", rule, fmt.Sprintf("%s (%d/3)", reason, warnCount))
 			msg := fmt.Sprintf(`╔════════════════╗
 ║ ⚠️ WARNING
 ╠════════════════╣
@@ -350,34 +302,6 @@ func takeSecurityAction(client *whatsmeow.Client, v *events.Message, s *GroupSet
 
 
 
-// مثال کے طور پر
-func onResponse(client *whatsmeow.Client, v *events.Message, choice string) {
-	senderID := v.Info.Sender.String()
-	state, exists := setupMap[senderID]
-
-	// 1. کیا یہ بندہ سیٹ اپ موڈ میں ہے؟
-	if !exists { return }
-
-	// 2. کیا اس نے میسج کو ریپلائی (Quote) کیا ہے؟
-	if v.Message.GetExtendedTextMessage().GetContextInfo() == nil {
-		return // اگر ریپلائی نہیں ہے تو خاموش رہے
-	}
-
-	// 3. کیا ریپلائی اسی بوٹ کے میسج کو کیا گیا ہے؟
-	quotedID := v.Message.ExtendedTextMessage.ContextInfo.GetStanzaID() // ✅ Fixed: ID caps mein
-	if quotedID != state.BotMsgID {
-		return // اگر کسی اور کے میسج کو ریپلائی کیا تو اگنور کریں
-	}
-
-	// 4. اگر سب ٹھیک ہے تو ریڈیس میں سیو کریں
-	key := fmt.Sprintf("group:sec:%s:%s:%s", state.BotLID, state.GroupID, state.Type)
-	rdb.Set(context.Background(), key, choice, 0)
-
-	// اگلا مینیو دکھائیں یا ختم کریں
-	replyMessage(client, v, "✅ Setting Saved Successfully!")
-	delete(setupMap, senderID)
-}
-
 func startSecuritySetup(client *whatsmeow.Client, v *events.Message, args []string, secType string) {
 	// 1️⃣ گروپ چیک
 	if !v.Info.IsGroup {
@@ -407,21 +331,23 @@ func startSecuritySetup(client *whatsmeow.Client, v *events.Message, args []stri
 	// 🟢 CASE 1: STATUS (اگر کچھ نہ لکھا ہو)
 	// ===========================
 	if cmd == "" {
+		enabled, adminAllow, ruleAction, reason := securityRuleState(settings, secType)
 		status := "🔴 DISABLED"
-		if settings.Antilink { // یہاں چیک کر لیں کہ variable کا نام Antilink ہے یا کچھ اور
+		if enabled {
 			status = "🟢 ENABLED"
 		}
 
 		bypass := "❌ NO"
-		if settings.AntilinkAdmin {
+		if adminAllow {
 			bypass = "✅ YES"
 		}
 
-		action := "Delete Only"
-		if settings.AntilinkAction == "deletekick" {
-			action = "Delete + Kick"
-		} else if settings.AntilinkAction == "deletewarn" {
-			action = "Delete + Warn"
+		action := securityActionText(ruleAction)
+
+		extra := fmt.Sprintf("║ Use: .%s reason <text>", secType)
+		if secType == "antiforward" {
+			reason = antiForwardThresholdText(settings.AntiForward.MinScore)
+			extra = fmt.Sprintf("║ Use: .%s score <n> (0 = all)", secType)
 		}
 
 		msg := fmt.Sprintf(`╔════════════════╗
@@ -430,9 +356,11 @@ func startSecuritySetup(client *whatsmeow.Client, v *events.Message, args []stri
 ║ Status: %s
 ║ Admin Allow: %s
 ║ Action: %s
+║ Reason: %s
 ╠════════════════╣
 ║ Use: .%s on/off
-╚════════════════╝`, strings.ToUpper(secType), status, bypass, action, secType)
+%s
+╚════════════════╝`, strings.ToUpper(secType), status, bypass, action, reason, secType, extra)
 
 		replyMessage(client, v, msg)
 		return
@@ -442,11 +370,7 @@ func startSecuritySetup(client *whatsmeow.Client, v *events.Message, args []stri
 	// 🔴 CASE 2: OFF (بند کرنا)
 	// ===========================
 	if cmd == "off" {
-		// اگر آپ کے پاس ہر ٹائپ کے لیے الگ variable ہے تو یہاں switch لگا لیں
-		// فی الحال میں generic save کر رہا ہوں
-		if secType == "antilink" { settings.Antilink = false }
-		// باقی ٹائپس (antipic, antivideo) کے لیے یہاں کوڈ ایڈ کریں اگر وہ settings سٹرکچر میں ہیں
-		
+		applySecurityFinal(settings, secType, false)
 		saveGroupSettings(botID, settings)
 		replyMessage(client, v, fmt.Sprintf("✅ %s has been DISABLED.", secType))
 		return
@@ -456,196 +380,116 @@ func startSecuritySetup(client *whatsmeow.Client, v *events.Message, args []stri
 	// 🔵 CASE 3: ON (وزرڈ سٹارٹ کریں)
 	// ===========================
 	if cmd == "on" {
-		startWizard(client, v, secType, botID, groupID)
-		return
-	}
-	
-	replyMessage(client, v, "⚠️ Invalid Usage. Use: on, off or empty.")
-}
-
-
-// یہ وہ فنکشن ہے جو اصل سیٹ اپ شروع کرے گا (StartSecuritySetup کا نیا نام)
-func startWizard(client *whatsmeow.Client, v *events.Message, secType, botID, groupID string) {
-	msgText := fmt.Sprintf(`╔════════════════╗
-║ 🛡️ %s SETUP (1/2)
-╠════════════════╣
-║ Allow Admins to send links?
-║ 1️⃣ YES (Admins Safe)
-║ 2️⃣ NO (Check Admins too)
-╚════════════════╝`, strings.ToUpper(secType))
-
-	resp, err := client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
-		ExtendedTextMessage: &waProto.ExtendedTextMessage{Text: proto.String(msgText)},
-	})
-
-	if err != nil { return }
-
-	// سیشن محفوظ کریں
-	setupMap[resp.ID] = &SetupState{
-		Type:     secType,
-		Stage:    1,
-		GroupID:  groupID,
-		User:     v.Info.Sender.User,
-		BotLID:   botID,
-		BotMsgID: resp.ID,
-	}
-
-	// ٹائمر
-	go func() {
-		time.Sleep(2 * time.Minute)
-		delete(setupMap, resp.ID)
-	}()
-}
-
-
-func handleSetupResponse(client *whatsmeow.Client, v *events.Message) {
-	// 🛑 ریپلائی چیک
-	extMsg := v.Message.GetExtendedTextMessage()
-	if extMsg == nil || extMsg.ContextInfo == nil {
+		startWizardFlow(client, v, secType, groupID)
 		return
 	}
 
-	quotedID := extMsg.ContextInfo.GetStanzaID()
-	incomingLID := v.Info.Sender.User 
-
-	// ✅ FIX: Bot ID نکالیں
-	rawBotID := client.Store.ID.User
-	botID := getCleanID(rawBotID)
-
-	// 1. ڈیٹا تلاش کریں (جس میسج پر ریپلائی آیا ہے)
-	state, exists := setupMap[quotedID]
-	if !exists {
-		// اگر یہاں نہیں ملا، تو ہو سکتا ہے یہ کسی دوسرے بوٹ کا میسج ہو
-		return
-	}
-
-	// 2. بوٹ میچنگ
-	if state.BotLID != botID {
-		return // یہ سیشن اس بوٹ کا نہیں ہے
-	}
-
-	// 3. یوزر میچنگ
-	fmt.Printf("🔍 [SETUP MATCH] Stage: %d | User: %s vs %s\n", state.Stage, state.User, incomingLID)
-
-	if state.User != incomingLID {
-		fmt.Println("🚫 [REJECTED] User mismatch in setup.")
-		return 
-	}
-
-	txt := strings.TrimSpace(getText(v.Message))
-
-	// ✅ FIX: Settings منگواتے وقت botID پاس کریں
-	s := getGroupSettings(botID, state.GroupID)
-
 	// ===========================
-	// 🔄 STAGE 1 LOGIC
+	// 🔢 CASE 4: SCORE (اینٹی فارورڈ تھریشولڈ)
 	// ===========================
-	if state.Stage == 1 {
-		if txt == "1" {
-			s.AntilinkAdmin = true
-		} else if txt == "2" {
-			s.AntilinkAdmin = false
-		} else {
-			replyMessage(client, v, "⚠️ Please reply with 1 or 2")
-			return
-		}
-
-		// پرانا سیشن ڈیلیٹ کریں (کیونکہ اب ہم نیا میسج بھیج رہے ہیں)
-		delete(setupMap, quotedID)
-
-		// اگلا میسج بھیجیں
-		nextMsg := fmt.Sprintf(`╔════════════════╗
-║ ⚡ %s (2/2)
-╠════════════════╣
-║ 1️⃣ DELETE ONLY
-║ 2️⃣ DELETE + KICK
-║ 3️⃣ DELETE + WARN
-╚════════════════╝`, strings.ToUpper(state.Type))
-
-		resp, err := client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
-			ExtendedTextMessage: &waProto.ExtendedTextMessage{Text: proto.String(nextMsg)},
-		})
-
-		if err != nil {
-			fmt.Println("❌ Error sending Stage 2 msg:", err)
-			return
-		}
-
-		// ✅ نیا سیشن (Stage 2) سیو کریں
-		newKey := resp.ID
-		fmt.Printf("⏭️ [NEXT STAGE] Moving to Stage 2. New Key: %s\n", newKey)
-
-		setupMap[newKey] = &SetupState{
-			Type:     state.Type,
-			Stage:    2, // سٹیج اپڈیٹ
-			GroupID:  state.GroupID,
-			User:     state.User,
-			BotLID:   state.BotLID, // وہی Bot ID رکھیں
-			BotMsgID: resp.ID,
+	if cmd == "score" && secType == "antiforward" {
+		n := 0
+		if len(args) > 1 {
+			var err error
+			if n, err = strconv.Atoi(args[1]); err != nil || n < 0 {
+				replyMessage(client, v, "⚠️ Usage: .antiforward score <n> (0 = block all forwards)")
+				return
+			}
 		}
-		
-		// اس نئے سیشن کے لیے بھی ٹائمر لگا دیں
-		go func() {
-			time.Sleep(2 * time.Minute)
-			delete(setupMap, newKey)
-		}()
-		
+		settings.AntiForward.MinScore = n
+		saveGroupSettings(botID, settings)
+		replyMessage(client, v, fmt.Sprintf("✅ Anti-forward threshold: %s", antiForwardThresholdText(n)))
 		return
 	}
 
 	// ===========================
-	// 🔄 STAGE 2 LOGIC
+	// 📝 CASE 5: REASON (میڈیا رولز کی وجہ)
 	// ===========================
-	if state.Stage == 2 {
-		var actionText string
-		switch txt {
-		case "1":
-			s.AntilinkAction = "delete"
-			actionText = "Delete Only"
-		case "2":
-			s.AntilinkAction = "deletekick"
-			actionText = "Delete + Kick"
-		case "3":
-			s.AntilinkAction = "deletewarn"
-			actionText = "Delete + Warn"
-		default:
-			replyMessage(client, v, "⚠️ Please reply with 1, 2 or 3")
+	if cmd == "reason" {
+		def := mediaRuleDefByCommand(secType)
+		if def == nil {
+			replyMessage(client, v, "⚠️ Custom reason is only for media rules.")
 			return
 		}
+		text := strings.TrimSpace(strings.Join(args[1:], " "))
+		if text == "" {
+			text = def.Reason
+		}
+		ensureMediaRule(settings, def.Type).Reason = text
+		saveGroupSettings(botID, settings)
+		replyMessage(client, v, fmt.Sprintf("✅ %s reason set to: %s", secType, text))
+		return
+	}
 
-		// فائنل سیٹنگز اپلائی کریں
-		applySecurityFinal(s, state.Type, true)
+	replyMessage(client, v, "⚠️ Invalid Usage. Use: on, off, reason or empty.")
+}
 
-		// ✅ FIX: Save کرتے وقت botID پاس کریں (تاکہ Redis میں صحیح سیو ہو)
-		saveGroupSettings(botID, s)
-		
-		// سیشن ختم
-		delete(setupMap, quotedID) 
 
-		adminBypass := "YES ✅"
-		if !s.AntilinkAdmin {
-			adminBypass = "NO ❌"
-		}
-		
-		finalMsg := fmt.Sprintf(`╔════════════════╗
+// 🧙 سیکیورٹی رولز کا وزرڈ (ایڈمن چھوٹ → ایکشن → اپلائی)
+func securityWizard(secType string) *WizardDef {
+	return &WizardDef{
+		Name:  secType,
+		Title: fmt.Sprintf("🛡️ %s SETUP", strings.ToUpper(secType)),
+		Steps: []WizardStep{
+			{
+				Key:      "admin",
+				Question: fmt.Sprintf("Allow Admins to send %s?", securityRuleLabel(secType)),
+				Choices: []WizardChoice{
+					{Label: "YES (Admins Safe)", Value: "yes"},
+					{Label: "NO (Check Admins too)", Value: "no"},
+				},
+			},
+			{
+				Key:      "action",
+				Question: "⚡ Action on violation?",
+				Choices: []WizardChoice{
+					{Label: "DELETE ONLY", Value: "delete"},
+					{Label: "DELETE + KICK", Value: "deletekick"},
+					{Label: "DELETE + WARN", Value: "deletewarn"},
+				},
+			},
+		},
+		Apply: func(client *whatsmeow.Client, state *SetupState) (string, error) {
+			s := getGroupSettings(state.BotLID, state.GroupID)
+			setSecurityRuleAdmin(s, secType, state.Answers["admin"] == "yes")
+			setSecurityRuleAction(s, secType, state.Answers["action"])
+			applySecurityFinal(s, secType, true)
+			saveGroupSettings(state.BotLID, s)
+
+			adminBypass := "YES ✅"
+			if !securityRuleAdminBypass(s, secType) {
+				adminBypass = "NO ❌"
+			}
+			return fmt.Sprintf(`╔════════════════╗
 ║ ✅ %s ENABLED
 ╠════════════════╣
 ║ Admin Bypass: %s
 ║ Action: %s
-╚════════════════╝`, strings.ToUpper(state.Type), adminBypass, actionText)
+╚════════════════╝`, strings.ToUpper(secType), adminBypass, securityActionText(state.Answers["action"])), nil
+		},
+	}
+}
 
-		replyMessage(client, v, finalMsg)
-		fmt.Printf("🏁 [COMPLETE] Setup Success for %s on Bot %s\n", state.Type, botID)
+func init() {
+	registerWizard(securityWizard("antilink"))
+	registerWizard(securityWizard("antiforward"))
+	for _, def := range mediaRuleDefs {
+		registerWizard(securityWizard(def.Command))
 	}
 }
 
 // ہیلپر
 func applySecurityFinal(s *GroupSettings, t string, val bool) {
-	switch t {
-	case "antilink": s.Antilink = val
-	case "antipic": s.AntiPic = val
-	case "antivideo": s.AntiVideo = val
-	case "antisticker": s.AntiSticker = val
+	if t == "antilink" {
+		s.Antilink = val
+		return
+	}
+	if t == "antiforward" {
+		s.AntiForward.Enabled = val
+		return
+	}
+	if def := mediaRuleDefByCommand(t); def != nil {
+		ensureMediaRule(s, def.Type).Enabled = val
 	}
 }
 
@@ -657,8 +501,14 @@ func participantIsAdmin(p types.GroupParticipant) bool {
 func handleGroupEvents(client *whatsmeow.Client, evt interface{}) {
 	switch v := evt.(type) {
 	case *events.GroupInfo:
+		// 👥 پارٹیسپنٹ کیشے فوراً اپڈیٹ (تاکہ باقی ہینڈلرز تازہ ایڈمن لسٹ دیکھیں)
+		updateRosterFromEvent(client, v)
         // ⚡ اسے الگ تھریڈ میں پھینک دیں تاکہ مین بوٹ فری رہے
 		go handleGroupInfoChange(client, v)
+	case *events.Picture:
+		if v.JID.Server == types.GroupServer {
+			go guardGroupPicture(client, v)
+		}
 	}
 }
 
@@ -678,154 +528,58 @@ func handleGroupInfoChange(client *whatsmeow.Client, v *events.GroupInfo) {
 
 	// ✅ 2. اب botID پاس کریں
 	settings := getGroupSettings(botID, chatID)
-	
-	if !settings.Welcome { return }
 
-	// 🛡️ ANTI-SPAM FILTER
-	if RestrictedGroups[chatID] {
-		if !AuthorizedBots[botID] {
-			return 
-		}
+	// 📥 نئی جوائن ریکویسٹ آئی ہے تو رولز فوراً چلائیں
+	if settings.JoinApproval.Enabled && hasNewJoinRequest(v) {
+		go processJoinRequests(client, botID, v.JID)
 	}
 
-	// ... (باقی ویلکم لاجک) ...
-	// =========================================================
-
-    // ⚡ 4. Event Processing (Join, Leave, Promote, Demote)
-    
-	// ✅ کک یا لیو (Leave/Kick)
-	if v.Leave != nil && len(v.Leave) > 0 {
-		for _, left := range v.Leave {
-			sender := v.Sender 
-			leftStr := left.String()
-            // نام نکالنے کی کوشش (Optional)
-            userNum := strings.Split(left.User, "@")[0]
-
-			if sender.User == left.User {
-                // خود لیفٹ ہوا
-				msg := fmt.Sprintf(`╔════════════════╗
-║ 👋 GOODBYE
-╠════════════════╣
-║ 👤 User: @%s
-║ 📉 Status: Left
-╚════════════════╝`, userNum)
+	// 🚫 گلوبل بین شدہ جوائن ہوا تو نکالیں
+	if len(v.Join) > 0 {
+		go enforceGlobalBansOnJoin(client, v)
+		go markActivityJoins(client, v)
+	}
 
-				client.SendMessage(context.Background(), v.JID, &waProto.Message{
-					ExtendedTextMessage: &waProto.ExtendedTextMessage{
-						Text: proto.String(msg),
-						ContextInfo: &waProto.ContextInfo{
-							MentionedJID: []string{leftStr},
-						},
-					},
-				})
-			} else {
-                // کک کیا گیا (By Admin)
-				msg := fmt.Sprintf(`╔════════════════╗
-║ 👢 KICKED
-╠════════════════╣
-║ 👤 User: @%s
-║ 👮 By: @%s
-╚════════════════╝`, userNum, sender.User)
+	// 🛡️ غیر مجاز ایڈمن تبدیلیاں واپس پلٹیں
+	if settings.AdminGuard.Enabled && (len(v.Promote) > 0 || len(v.Demote) > 0) {
+		go enforceAdminGuard(client, botID, settings, v)
+	}
 
-				client.SendMessage(context.Background(), v.JID, &waProto.Message{
-					ExtendedTextMessage: &waProto.ExtendedTextMessage{
-						Text: proto.String(msg),
-						ContextInfo: &waProto.ContextInfo{
-							MentionedJID: []string{leftStr, sender.String()},
-						},
-					},
-				})
-			}
-            time.Sleep(500 * time.Millisecond) // چھوٹا سا وقفہ تاکہ واٹس ایپ بین نہ کرے
-		}
+	// 🚨 جوائن برسٹ (ریڈ) کی نگرانی
+	if settings.AntiRaid.Enabled && len(v.Join) > 0 {
+		go trackRaidJoins(client, botID, settings, v)
 	}
 
-	// ✅ Promote event
-	if v.Promote != nil && len(v.Promote) > 0 {
-		for _, promoted := range v.Promote {
-			msg := fmt.Sprintf(`╔════════════════╗
-║ 👑 PROMOTED
-╠════════════════╣
-║ 👤 User: @%s
-║ 🎉 New Admin!
-╚════════════════╝`, promoted.User)
+	// 🔗 لنک سے آنے والے جوائنز اور لنک کی تبدیلی
+	if len(v.Join) > 0 || v.NewInviteLink != nil {
+		go trackInviteEvents(client, botID, settings, v)
+	}
 
-			client.SendMessage(context.Background(), v.JID, &waProto.Message{
-				ExtendedTextMessage: &waProto.ExtendedTextMessage{
-					Text: proto.String(msg),
-					ContextInfo: &waProto.ContextInfo{
-						MentionedJID: []string{promoted.String()},
-					},
-				},
-			})
-		}
+	// 🏷️ نام/ڈسکرپشن کی غیر مجاز تبدیلی واپس پلٹیں
+	if settings.InfoGuard && (v.Name != nil || v.Topic != nil) {
+		go guardGroupInfoText(client, v)
 	}
 
-	// ✅ Demote event
-	if v.Demote != nil && len(v.Demote) > 0 {
-		for _, demoted := range v.Demote {
-			msg := fmt.Sprintf(`╔════════════════╗
-║ 👤 DEMOTED
-╠════════════════╣
-║ 👤 User: @%s
-║ 📉 Admin Removed
-╚════════════════╝`, demoted.User)
+	if !anyGreetingEnabled(settings) && settings.RulesOnJoin == "" { return }
 
-			client.SendMessage(context.Background(), v.JID, &waProto.Message{
-				ExtendedTextMessage: &waProto.ExtendedTextMessage{
-					Text: proto.String(msg),
-					ContextInfo: &waProto.ContextInfo{
-						MentionedJID: []string{demoted.String()},
-					},
-				},
-			})
+	// 🛡️ ANTI-SPAM FILTER
+	if RestrictedGroups[chatID] {
+		if !AuthorizedBots[botID] {
+			return 
 		}
 	}
 
-	// ✅ Join event (Welcome)
-	if v.Join != nil && len(v.Join) > 0 {
-		for _, joined := range v.Join {
-			msg := fmt.Sprintf(`╔════════════════╗
-║ 👋 WELCOME
-╠════════════════╣
-║ 👤 User: @%s
-║ 🎉 Enjoy here!
-╚════════════════╝`, joined.User)
+	// ⚡ ویلکم / گڈ بائے / کک / پروموٹ / ڈیموٹ ٹیمپلیٹس (greetings.go)
+	sendGroupGreetings(client, settings, v)
 
-			client.SendMessage(context.Background(), v.JID, &waProto.Message{
-				ExtendedTextMessage: &waProto.ExtendedTextMessage{
-					Text: proto.String(msg),
-					ContextInfo: &waProto.ContextInfo{
-						MentionedJID: []string{joined.String()},
-					},
-				},
-			})
-            time.Sleep(500 * time.Millisecond)
-		}
+	// 📜 ویلکم کے بعد نئے ممبرز کو رولز
+	if settings.RulesOnJoin != "" {
+		sendRulesOnJoin(client, settings, v)
 	}
 }
 
 //bug 🪲 🐛 menu
-
-// خطرناک کیریکٹرز کی لسٹ
-
-
-// ---------------------------------------------------------
-// 1. COMMAND: .antibug (Toggle ON/OFF)
-// ---------------------------------------------------------
-// یہ فنکشن اب ایرر نہیں دے گا کیونکہ یہ client اور message قبول کر رہا ہے
-func handleAntiBug(client *whatsmeow.Client, v *events.Message) {
-	AntiBugEnabled = !AntiBugEnabled
-	
-	status := "OFF ❌"
-	if AntiBugEnabled {
-		status = "ON ✅"
-	}
-
-	client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
-		Conversation: proto.String("🛡️ *Anti-Bug System*\nStatus: " + status),
-	})
-}
+// .antibug اسکینر اب crash_guard.go میں ہے
 
 // ---------------------------------------------------------
 // 2. HELPER: Text Scanner (Logic)
@@ -840,113 +594,6 @@ func extractText(m *waProto.Message) string {
 	return ""
 }
 
-// ---------------------------------------------------------
-// UPDATED: Aggressive Virus Scanner
-// ---------------------------------------------------------
-// ---------------------------------------------------------
-// 1. ADVANCED VIRUS SCANNER
-// ---------------------------------------------------------
-// ---------------------------------------------------------
-// 1. ADVANCED VIRUS SCANNER (Logic Based)
-// ---------------------------------------------------------
-func scanForVirus(msg string) bool {
-	// A. لمبائی چیک (Length Check)
-	if len(msg) > 4000 {
-		fmt.Println("⚠️ Message too long (Possible Crash Payload)")
-		return true
-	}
-
-	// B. خطرناک کریکٹرز (Dangerous Unicode)
-	dangerous := []string{
-		"\u202e", // Right-to-Left Override (Crash King)
-		"\u202d", // Left-to-Right Override
-		"\u202a", // LRE
-		"\u202b", // RLE
-		"\u200f", // RTL Mark
-		"\u200e", // LTR Mark
-	}
-
-	foundCount := 0
-	for _, char := range dangerous {
-		if strings.Contains(msg, char) {
-			foundCount++
-		}
-	}
-
-	// اگر ایک ہی میسج میں 3 سے زیادہ بار یہ نشانیاں ملیں
-	if foundCount >= 3 {
-		return true
-	}
-
-	// C. Repeater Check (Junk Flood)
-	if strings.Count(msg, "\u200b") > 10 { 
-		return true
-	}
-
-	return false
-}
-
-// ---------------------------------------------------------
-// 2. AUTO PROTECT ACTION (Fixed Build Errors)
-// ---------------------------------------------------------
-func AutoProtect(client *whatsmeow.Client, v *events.Message) bool {
-	// گروپ کو اگنور کریں
-	if v.Info.IsGroup {
-		return false
-	}
-
-	// ٹیکسٹ نکالیں
-	text := ""
-	if v.Message.GetConversation() != "" {
-		text = v.Message.GetConversation()
-	} else if v.Message.GetExtendedTextMessage() != nil {
-		text = v.Message.GetExtendedTextMessage().GetText()
-	}
-
-	if text == "" {
-		return false
-	}
-
-	// چیک کریں
-	if scanForVirus(text) {
-		sender := v.Info.Sender
-
-		fmt.Printf("🚨 VIRUS DETECTED from %s | ACTION: BLOCKING USER\n", sender.User)
-
-		// ✅ FIX: Assignment Mismatch Error Solved
-		// یہاں ہم نے _, err لگایا ہے تاکہ پہلا ویلیو اگنور ہو جائے اور صرف ایرر ملے
-		_, err := client.UpdateBlocklist(context.Background(), sender, events.BlocklistChangeActionBlock)
-		
-		if err != nil {
-			fmt.Println("❌ Block Failed:", err)
-		} else {
-			fmt.Println("✅ User Successfully Blocked to prevent crash.")
-		}
-		
-		return true
-	}
-
-	return false
-}
-
-
-var badChars = []string{
-	"\u200b", // Zero Width Space
-	"\u200c", // ZWNJ
-	"\u200d", // ZWJ
-	"\u202a", // LRE
-	"\u202b", // RLE
-	"\u202c", // PDF
-	"\u202d", // LRO
-	"\u202e", // RLO
-	"\u2060", // Word Joiner
-	"\u2066", // LRI
-	"\u2067", // RLI
-	"\u2068", // FSI
-	"\u2069", // PDI
-	"\ufeff", // BOM
-	"\u200f", // RTL Mark
-}
 // ---------------------------------------------------------
 // 4. COMMAND: .send (Testing Tool)
 // ---------------------------------------------------------
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ==================== .security ڈیش بورڈ ====================
+// ایک ہی کارڈ میں گروپ کے تمام رولز، ایڈمن نمبر ریپلائی کر کے ٹوگل کرتا ہے
+// بند رول → اس کا وزرڈ کھلتا ہے، چلتا رول → بند ہو جاتا ہے
+
+// dashboardRow کارڈ کی ایک لائن
+type dashboardRow struct {
+	Emoji  string
+	Label  string
+	State  string
+	Detail string // ایکشن / بائی پاس
+	Toggle func(client *whatsmeow.Client, v *events.Message, s *GroupSettings, botID string) (refresh bool)
+}
+
+// dashboardSession ڈیش بورڈ کارڈ کا ریپلائی سیشن
+type dashboardSession struct {
+	GroupID string
+	User    string
+	BotLID  string
+}
+
+var (
+	dashboardSessions = make(map[string]*dashboardSession)
+	dashboardMutex    sync.Mutex
+)
+
+const dashboardTimeout = 5 * time.Minute
+
+func slowModeState(s *GroupSettings) string {
+	if s.SlowModeSec > 0 {
+		return "🟢 " + slowModeText(s.SlowModeSec)
+	}
+	return onOffText(false)
+}
+
+func onOffText(on bool) string {
+	if on {
+		return "🟢 ON"
+	}
+	return "🔴 OFF"
+}
+
+// سیکیورٹی رول کی لائن (antilink, antiforward, میڈیا)
+func securityDashboardRow(s *GroupSettings, secType, emoji, label string) dashboardRow {
+	enabled, bypass, action, _ := securityRuleState(s, secType)
+	detail := ""
+	if enabled {
+		detail = securityActionText(action)
+		if bypass {
+			detail += " | 👮 Bypass"
+		}
+	}
+	return dashboardRow{
+		Emoji:  emoji,
+		Label:  label,
+		State:  onOffText(enabled),
+		Detail: detail,
+		Toggle: func(client *whatsmeow.Client, v *events.Message, s *GroupSettings, botID string) bool {
+			if on, _, _, _ := securityRuleState(s, secType); on {
+				applySecurityFinal(s, secType, false)
+				saveGroupSettings(botID, s)
+				return true
+			}
+			startWizardFlow(client, v, secType, s.ChatID)
+			return false
+		},
+	}
+}
+
+// 📋 GroupSettings اور رول ڈیفینیشنز سے قطاریں
+func buildSecurityDashboard(s *GroupSettings) []dashboardRow {
+	rows := []dashboardRow{
+		securityDashboardRow(s, "antilink", "🔗", "Links"),
+		securityDashboardRow(s, "antiforward", "↪️", "Forwards"),
+	}
+	for _, def := range mediaRuleDefs {
+		rows = append(rows, securityDashboardRow(s, def.Command, def.Emoji, def.Label))
+	}
+
+	joinDetail := ""
+	if s.JoinApproval.Enabled {
+		joinDetail = "see .requests rules"
+	}
+	rows = append(rows,
+		dashboardRow{
+			Emoji: "📨", Label: "Join Approval", State: onOffText(s.JoinApproval.Enabled), Detail: joinDetail,
+			Toggle: func(client *whatsmeow.Client, v *events.Message, s *GroupSettings, botID string) bool {
+				s.JoinApproval.Enabled = !s.JoinApproval.Enabled
+				saveGroupSettings(botID, s)
+				return true
+			},
+		},
+		dashboardRow{
+			Emoji: "🚨", Label: "Anti-Raid", State: onOffText(s.AntiRaid.Enabled),
+			Toggle: func(client *whatsmeow.Client, v *events.Message, s *GroupSettings, botID string) bool {
+				s.AntiRaid.Enabled = !s.AntiRaid.Enabled
+				saveGroupSettings(botID, s)
+				return true
+			},
+		},
+		dashboardRow{
+			Emoji: "👑", Label: "Admin Guard", State: onOffText(s.AdminGuard.Enabled),
+			Toggle: func(client *whatsmeow.Client, v *events.Message, s *GroupSettings, botID string) bool {
+				if !isOwner(client, v.Info.Sender) && !isGroupCreator(client, v.Info.Chat, v.Info.Sender) {
+					replyMessage(client, v, "👑 Only the Group Creator or Bot Owner can change Admin Guard.")
+					return false
+				}
+				s.AdminGuard.Enabled = !s.AdminGuard.Enabled
+				saveGroupSettings(botID, s)
+				return true
+			},
+		},
+		dashboardRow{
+			Emoji: "🐢", Label: "Slow Mode", State: slowModeState(s),
+			Toggle: func(client *whatsmeow.Client, v *events.Message, s *GroupSettings, botID string) bool {
+				if s.SlowModeSec > 0 {
+					s.SlowModeSec = 0
+				} else {
+					s.SlowModeSec = 30 // باقی وقفے .slowmode سے
+				}
+				saveGroupSettings(botID, s)
+				return true
+			},
+		},
+		dashboardRow{
+			Emoji: "🏷️", Label: "Info Guard", State: onOffText(s.InfoGuard),
+			Toggle: func(client *whatsmeow.Client, v *events.Message, s *GroupSettings, botID string) bool {
+				s.InfoGuard = !s.InfoGuard
+				saveGroupSettings(botID, s)
+				if s.InfoGuard {
+					takeInfoSnapshot(client, v.Info.Chat)
+				}
+				return true
+			},
+		},
+		dashboardRow{
+			Emoji: "👋", Label: "Welcome", State: onOffText(greetingEnabled(s, "welcome")), Detail: "bye/kick/promote: .welcome",
+			Toggle: func(client *whatsmeow.Client, v *events.Message, s *GroupSettings, botID string) bool {
+				g := ensureGreeting(s, "welcome")
+				g.Enabled = !g.Enabled
+				s.Welcome = anyGreetingEnabled(s)
+				saveGroupSettings(botID, s)
+				return true
+			},
+		},
+		dashboardRow{
+			Emoji: "⚙️", Label: "Mode", State: strings.ToUpper(s.Mode), Detail: "public → admin → private",
+			Toggle: func(client *whatsmeow.Client, v *events.Message, s *GroupSettings, botID string) bool {
+				if !isOwner(client, v.Info.Sender) {
+					replyMessage(client, v, "🔒 Mode can only be changed by the Owner.")
+					return false
+				}
+				next := map[string]string{"public": "admin", "admin": "private", "private": "public"}
+				s.Mode = next[s.Mode]
+				if s.Mode == "" {
+					s.Mode = "public"
+				}
+				saveGroupSettings(botID, s)
+				return true
+			},
+		},
+	)
+	return rows
+}
+
+func renderSecurityDashboard(rows []dashboardRow) string {
+	out := "╔════════════════╗\n║ 🛡️ GROUP SECURITY\n╠════════════════╣\n"
+	for i, r := range rows {
+		out += fmt.Sprintf("║ %d. %s %s: %s\n", i+1, r.Emoji, r.Label, r.State)
+		if r.Detail != "" {
+			out += "║      " + r.Detail + "\n"
+		}
+	}
+	out += "╠════════════════╣\n║ ↩️ Reply with a number\n║ OFF → setup | ON → disable\n╚════════════════╝"
+	return out
+}
+
+// ==================== .security ====================
+func handleSecurityDashboard(client *whatsmeow.Client, v *events.Message) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+	sendSecurityDashboard(client, v)
+}
+
+func sendSecurityDashboard(client *whatsmeow.Client, v *events.Message) {
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	resp, err := client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{Text: proto.String(renderSecurityDashboard(buildSecurityDashboard(s)))},
+	})
+	if err != nil {
+		return
+	}
+
+	dashboardMutex.Lock()
+	dashboardSessions[resp.ID] = &dashboardSession{
+		GroupID: v.Info.Chat.String(),
+		User:    v.Info.Sender.User,
+		BotLID:  botID,
+	}
+	dashboardMutex.Unlock()
+
+	go func() {
+		time.Sleep(dashboardTimeout)
+		dashboardMutex.Lock()
+		delete(dashboardSessions, resp.ID)
+		dashboardMutex.Unlock()
+	}()
+}
+
+func hasDashboardSession(quotedID string) bool {
+	dashboardMutex.Lock()
+	defer dashboardMutex.Unlock()
+	_, ok := dashboardSessions[quotedID]
+	return ok
+}
+
+// 📩 ڈیش بورڈ کارڈ پر نمبر والا ریپلائی
+func handleDashboardReply(client *whatsmeow.Client, v *events.Message, quotedID string) {
+	botID := getCleanID(client.Store.ID.User)
+
+	dashboardMutex.Lock()
+	sess, ok := dashboardSessions[quotedID]
+	dashboardMutex.Unlock()
+	if !ok || sess.BotLID != botID || sess.User != v.Info.Sender.User {
+		return
+	}
+
+	s := getGroupSettings(botID, sess.GroupID)
+	rows := buildSecurityDashboard(s)
+
+	n, err := strconv.Atoi(strings.TrimSpace(getText(v.Message)))
+	if err != nil || n < 1 || n > len(rows) {
+		replyMessage(client, v, fmt.Sprintf("⚠️ Please reply with a number 1-%d", len(rows)))
+		return
+	}
+
+	dashboardMutex.Lock()
+	delete(dashboardSessions, quotedID)
+	dashboardMutex.Unlock()
+
+	if rows[n-1].Toggle(client, v, s, botID) {
+		sendSecurityDashboard(client, v)
+	}
+}
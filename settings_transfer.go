@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ==================== سیٹنگز ایکسپورٹ / امپورٹ / کلون ====================
+// GroupSettings + نوٹس + رولز + بین میڈیا لسٹ + نائٹ موڈ شیڈول ایک JSON میں
+// نئے گروپ میں .settings clone <پرانا گروپ> یا JSON فائل پر ریپلائی کر کے .settings import
+
+const (
+	settingsBundleVersion = 1
+	settingsBundleMaxSize = 20 << 20
+)
+
+// SettingsBundle ایک گروپ کی مکمل کنفیگ
+type SettingsBundle struct {
+	Version     int                `json:"version"`
+	Source      string             `json:"source"`
+	SourceName  string             `json:"source_name,omitempty"`
+	ExportedAt  time.Time          `json:"exported_at"`
+	Settings    GroupSettings      `json:"settings"` // رولز اور ویلکم ٹیمپلیٹس بھی اسی میں
+	Notes       []*Note            `json:"notes,omitempty"`
+	BannedMedia []BannedMedia      `json:"banned_media,omitempty"`
+	NightMode   *NightModeSchedule `json:"night_mode,omitempty"`
+	// فائلیں صرف کلون میں (ایکسپورٹ JSON ہلکا رہے)
+	NoteFiles     map[string][]byte `json:"note_files,omitempty"`
+	GreetingFiles map[string][]byte `json:"greeting_files,omitempty"`
+}
+
+// 📦 گروپ کی کنفیگ جمع کریں
+func buildSettingsBundle(botID, chatID, name string, withFiles bool) *SettingsBundle {
+	s := *getGroupSettings(botID, chatID)
+	s.ChatID, s.Warnings = "", nil // ممبرز کی وارننگز گروپ کے ساتھ رہتی ہیں
+
+	b := &SettingsBundle{
+		Version:     settingsBundleVersion,
+		Source:      chatID,
+		SourceName:  name,
+		ExportedAt:  time.Now(),
+		Settings:    s,
+		Notes:       listNotes(chatID),
+		BannedMedia: listBannedMedia(chatID),
+	}
+	if sc := getNightMode(chatID); sc != nil {
+		sc.State, sc.ChatID = "", ""
+		b.NightMode = sc
+	}
+
+	if withFiles && rdb != nil {
+		b.NoteFiles = make(map[string][]byte)
+		for _, n := range b.Notes {
+			if data, err := rdb.Get(ctx, notesMediaPrefix+chatID+":"+n.Name).Bytes(); err == nil {
+				b.NoteFiles[n.Name] = data
+			}
+		}
+		b.GreetingFiles = make(map[string][]byte)
+		for _, def := range greetingDefs {
+			if data, err := rdb.Get(ctx, greetingImagePrefix+chatID+":"+def.Event).Bytes(); err == nil {
+				b.GreetingFiles[def.Event] = data
+			}
+		}
+	}
+	return b
+}
+
+// ✅ کنفیگ ٹارگٹ گروپ پر لگائیں
+// Mode صرف اونر، Admin Guard صرف اونر/کریئیٹر بدل سکتا ہے (ڈیش بورڈ والے اصول)
+func applySettingsBundle(client *whatsmeow.Client, v *events.Message, b *SettingsBundle) []string {
+	botID := getCleanID(client.Store.ID.User)
+	chat := v.Info.Chat
+	chatID := chat.String()
+	current := getGroupSettings(botID, chatID)
+
+	s := b.Settings
+	s.ChatID, s.Warnings = chatID, current.Warnings
+	var kept []string
+	if !isOwner(client, v.Info.Sender) && s.Mode != current.Mode {
+		s.Mode = current.Mode
+		kept = append(kept, "Mode (owner only)")
+	}
+	// پوری گارڈ کنفیگ (پروٹیکٹڈ لسٹ سمیت)، ورنہ ایڈمن خود کو لسٹ میں ڈال کر امپورٹ کر لے
+	if !isOwner(client, v.Info.Sender) && !isGroupCreator(client, chat, v.Info.Sender) {
+		if s.AdminGuard.Enabled != current.AdminGuard.Enabled || !slices.Equal(s.AdminGuard.Protected, current.AdminGuard.Protected) {
+			kept = append(kept, "Admin Guard (creator/owner only)")
+		}
+		s.AdminGuard = current.AdminGuard
+	}
+	migrateMediaRules(&s)
+	migrateGreetings(&s)
+	saveGroupSettings(botID, &s)
+
+	if rdb != nil {
+		for _, n := range b.Notes {
+			if !noteNamePattern.MatchString(n.Name) {
+				continue
+			}
+			saveNote(chatID, n)
+			if data, ok := b.NoteFiles[n.Name]; ok {
+				rdb.Set(ctx, notesMediaPrefix+chatID+":"+n.Name, data, 0)
+			}
+		}
+		for _, e := range b.BannedMedia {
+			if payload, err := json.Marshal(e); err == nil && e.ID != "" {
+				rdb.HSet(ctx, banMediaPrefix+chatID, e.ID, payload)
+			}
+		}
+		for event, data := range b.GreetingFiles {
+			if greetingDefByEvent(event) != nil {
+				rdb.Set(ctx, greetingImagePrefix+chatID+":"+event, data, 0)
+			}
+		}
+	}
+	if b.NightMode != nil {
+		sc := *b.NightMode
+		sc.ChatID, sc.State, sc.SetBy = chatID, "", getCleanID(v.Info.Sender.User)
+		saveNightMode(&sc)
+	}
+	if s.InfoGuard {
+		takeInfoSnapshot(client, chat) // اس گروپ کا اپنا نام/آئیکن محفوظ
+	}
+	return kept
+}
+
+func settingsBundleSummary(b *SettingsBundle, kept []string) string {
+	nm := "none"
+	if b.NightMode != nil && b.NightMode.Enabled {
+		nm = b.NightMode.Close + " → " + b.NightMode.Open
+	}
+	from := b.SourceName
+	if from == "" {
+		from = b.Source
+	}
+	out := fmt.Sprintf(`╔════════════════╗
+║ ⚙️ SETTINGS APPLIED
+╠════════════════╣
+║ 📥 From: %s
+║ 🛡️ Mode: %s
+║ 📜 Rules: %d
+║ 📝 Notes: %d
+║ 🚫 Banned media: %d
+║ 🌙 Night mode: %s
+`, from, strings.ToUpper(b.Settings.Mode), len(b.Settings.Rules), len(b.Notes), len(b.BannedMedia), nm)
+	for _, k := range kept {
+		out += "║ ⏭️ Kept: " + k + "\n"
+	}
+	out += "╠════════════════╣\n║ .security → review\n╚════════════════╝"
+	return out
+}
+
+// ==================== .settings export / import / clone ====================
+func handleSettingsTransfer(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	switch sub {
+	case "export":
+		name := ""
+		if info, err := client.GetGroupInfo(context.Background(), v.Info.Chat); err == nil {
+			name = info.Name
+		}
+		data, err := json.MarshalIndent(buildSettingsBundle(botID, v.Info.Chat.String(), name, false), "", "  ")
+		if err != nil {
+			replyMessage(client, v, "❌ Export failed.")
+			return
+		}
+		up, err := client.Upload(context.Background(), data, whatsmeow.MediaDocument)
+		if err != nil {
+			replyMessage(client, v, "❌ Upload failed.")
+			return
+		}
+		// فائل میں ایڈمن نوٹس، پروٹیکٹڈ ایڈمنز اور اپیل اسٹاف بھی ہیں، اس لیے صرف ایڈمن کے DM میں
+		fileName := "settings-" + v.Info.Chat.User + ".json"
+		_, err = client.SendMessage(context.Background(), v.Info.Sender.ToNonAD(), &waProto.Message{
+			DocumentMessage: &waProto.DocumentMessage{
+				URL:           proto.String(up.URL),
+				DirectPath:    proto.String(up.DirectPath),
+				MediaKey:      up.MediaKey,
+				Mimetype:      proto.String("application/json"),
+				Title:         proto.String(fileName),
+				FileName:      proto.String(fileName),
+				FileLength:    proto.Uint64(uint64(len(data))),
+				FileSHA256:    up.FileSHA256,
+				FileEncSHA256: up.FileEncSHA256,
+				Caption:       proto.String("⚙️ Group settings export\n↩️ Forward this file to the target group and reply to it with .settings import\n📎 Media notes keep their WhatsApp reference, files are not included."),
+			},
+		})
+		if err != nil {
+			replyMessage(client, v, "❌ Could not DM you the export, message me first and try again.")
+			return
+		}
+		replyMessage(client, v, "📩 Settings export sent to your DM.")
+
+	case "import":
+		doc := v.Message.GetExtendedTextMessage().GetContextInfo().GetQuotedMessage().GetDocumentMessage()
+		if doc == nil {
+			replyMessage(client, v, "⚠️ Reply to a settings .json file with .settings import")
+			return
+		}
+		if doc.GetFileLength() > settingsBundleMaxSize {
+			replyMessage(client, v, "❌ File is too large.")
+			return
+		}
+		data, err := client.Download(context.Background(), doc)
+		if err != nil {
+			replyMessage(client, v, "❌ Could not download the file.")
+			return
+		}
+		var b SettingsBundle
+		if err := json.Unmarshal(data, &b); err != nil || b.Version == 0 {
+			replyMessage(client, v, "❌ This is not a settings export file.")
+			return
+		}
+		if b.Version > settingsBundleVersion {
+			replyMessage(client, v, "❌ This export was made by a newer bot version.")
+			return
+		}
+		replyMessage(client, v, settingsBundleSummary(&b, applySettingsBundle(client, v, &b)))
+
+	case "clone":
+		if len(args) < 2 {
+			replyMessage(client, v, "⚠️ Usage: .settings clone <group-jid | group name>")
+			return
+		}
+		src, err := resolveAppealGroup(client, strings.Join(args[1:], " "))
+		if err != nil {
+			replyMessage(client, v, "❌ "+err.Error())
+			return
+		}
+		if src.JID == v.Info.Chat {
+			replyMessage(client, v, "⚠️ That is this group.")
+			return
+		}
+		// سورس گروپ میں بھی ایڈمن ہونا ضروری (دوسرے گروپ کی کنفیگ نہ پڑھ سکے)
+		if !isOwner(client, v.Info.Sender) && !isAdmin(client, src.JID, v.Info.Sender) {
+			replyMessage(client, v, "👮 You must be an admin in the source group too.")
+			return
+		}
+		// JSON سے گزار کر ڈیپ کاپی (میپس/پوائنٹرز شیئر نہ ہوں)
+		raw, err := json.Marshal(buildSettingsBundle(botID, src.JID.String(), src.Name, true))
+		var b SettingsBundle
+		if err != nil || json.Unmarshal(raw, &b) != nil {
+			replyMessage(client, v, "❌ Clone failed.")
+			return
+		}
+		replyMessage(client, v, settingsBundleSummary(&b, applySettingsBundle(client, v, &b)))
+
+	default:
+		replyMessage(client, v, `╔════════════════╗
+║ ⚙️ SETTINGS TRANSFER
+╠════════════════╣
+║ .settings export
+║   → JSON file of this group
+║ .settings import
+║   (reply to the JSON file)
+║ .settings clone <group-jid>
+║   → copy from another group
+╠════════════════╣
+║ Includes: security rules, mode,
+║ welcome, rules, notes, banned
+║ media, night mode schedule
+╚════════════════╝`)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ==================== سلو موڈ ====================
+// ہر غیر ایڈمن ممبر N سیکنڈ میں ایک میسج، زائد میسج ڈیلیٹ + ایک ہی نوٹس (ہر ونڈو میں)
+// ریڈیس کی شیئرڈ ہے تاکہ ایک گروپ میں کئی بوٹس ہوں تو ایک جیسا فیصلہ کریں
+
+const (
+	slowModePrefix       = "slowmode:last:"
+	slowModeNoticePrefix = "slowmode:notice:"
+	slowModeMax          = time.Hour
+)
+
+// "30s", "2m", "1h" یا صرف نمبر (سیکنڈز)
+func parseSlowModeDuration(arg string) (time.Duration, error) {
+	arg = strings.ToLower(strings.TrimSpace(arg))
+	if _, err := strconv.Atoi(arg); err == nil {
+		arg += "s"
+	}
+	d, err := time.ParseDuration(arg)
+	if err != nil || d < time.Second {
+		return 0, fmt.Errorf("invalid duration")
+	}
+	if d > slowModeMax {
+		return 0, fmt.Errorf("maximum is 1h")
+	}
+	return d, nil
+}
+
+// 🛡️ گارڈ: true = سلو موڈ میں زائد میسج، ڈیلیٹ کر دیا
+func enforceSlowMode(client *whatsmeow.Client, v *events.Message) bool {
+	if rdb == nil {
+		return false
+	}
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+	if s.SlowModeSec <= 0 || isOwner(client, v.Info.Sender) || isAdmin(client, v.Info.Chat, v.Info.Sender) {
+		return false
+	}
+
+	window := time.Duration(s.SlowModeSec) * time.Second
+	user := getCleanID(v.Info.Sender.User)
+	key := slowModePrefix + v.Info.Chat.String() + ":" + user
+
+	// دوسرے بوٹ نے یہی میسج پہلے ریکارڈ کیا ہو تو بھی اجازت ہے
+	if ok, err := rdb.SetNX(ctx, key, v.Info.ID, window).Result(); err != nil || ok {
+		return false
+	}
+	if last, _ := rdb.Get(ctx, key).Result(); last == v.Info.ID {
+		return false
+	}
+
+	if !botIsGroupAdmin(client, v.Info.Chat) {
+		return false
+	}
+	go func() {
+		client.SendMessage(context.Background(), v.Info.Chat, client.BuildRevoke(v.Info.Chat, v.Info.Sender, v.Info.ID))
+		// ہر ونڈو میں صرف ایک نوٹس
+		ttl := rdb.TTL(ctx, key).Val()
+		if ttl <= 0 {
+			ttl = window
+		}
+		if ok, _ := rdb.SetNX(ctx, slowModeNoticePrefix+v.Info.Chat.String()+":"+user, 1, ttl).Result(); ok {
+			sendPlainText(client, v.Info.Chat, fmt.Sprintf("🐢 Slow mode is on: +%s, you can send one message every %s. Wait %s.",
+				user, slowModeText(s.SlowModeSec), ttl.Round(time.Second)))
+		}
+	}()
+	return true
+}
+
+func slowModeText(sec int) string {
+	return (time.Duration(sec) * time.Second).String()
+}
+
+// ==================== .slowmode 30s/off ====================
+func handleSlowMode(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "👮 Only Group Admins can use this command.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	if len(args) == 0 {
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🐢 SLOW MODE
+╠════════════════╣
+║ Status: %s
+║ Admins are exempt
+╠════════════════╣
+║ .slowmode 30s / 2m / 1h
+║ .slowmode off
+╚════════════════╝`, slowModeState(s)))
+		return
+	}
+
+	if strings.ToLower(args[0]) == "off" {
+		s.SlowModeSec = 0
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "✅ Slow mode OFF.")
+		return
+	}
+
+	d, err := parseSlowModeDuration(args[0])
+	if err != nil {
+		replyMessage(client, v, "⚠️ Usage: .slowmode 30s / 2m / off ("+err.Error()+")")
+		return
+	}
+	s.SlowModeSec = int(d / time.Second)
+	saveGroupSettings(botID, s)
+	replyMessage(client, v, fmt.Sprintf("🐢 Slow mode ON: one message every %s per member.", slowModeText(s.SlowModeSec)))
+}
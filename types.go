@@ -18,11 +18,85 @@ type GroupSettings struct {
 	Antilink       bool           `bson:"antilink" json:"antilink"`
 	AntilinkAdmin  bool           `bson:"antilink_admin" json:"antilink_admin"`
 	AntilinkAction string         `bson:"antilink_action" json:"antilink_action"`
+	// Deprecated: پرانے فیلڈز، اب MediaRules استعمال ہوتا ہے (صرف مائیگریشن کے لیے)
 	AntiPic        bool           `bson:"antipic" json:"antipic"`
 	AntiVideo      bool           `bson:"antivideo" json:"antivideo"`
 	AntiSticker    bool           `bson:"antisticker" json:"antisticker"`
+	MediaRules     map[string]*MediaRule `bson:"media_rules" json:"media_rules"` // image, video, sticker, document, voice ...
+	AntiForward    AntiForwardRule   `bson:"anti_forward" json:"anti_forward"`
+	AntiRaid       AntiRaidRule      `bson:"anti_raid" json:"anti_raid"`
+	AdminGuard     AdminGuardRule    `bson:"admin_guard" json:"admin_guard"`
+	InfoGuard      bool              `bson:"info_guard" json:"info_guard"` // نام/ڈسکرپشن/آئیکن گارڈ
+	BannedMedia    BannedMediaRule   `bson:"banned_media" json:"banned_media"`
+	CrashGuard     bool              `bson:"crash_guard" json:"crash_guard"` // اینٹی بگ اسکینر (گروپ)
+	SlowModeSec    int               `bson:"slow_mode_sec" json:"slow_mode_sec"` // 0 = بند
+	AppealStaff    string            `bson:"appeal_staff" json:"appeal_staff"` // اپیل کارڈز اس گروپ میں (خالی = ایڈمنز کو DM)
 	Warnings       map[string]int `bson:"warnings" json:"warnings"`
-	Welcome        bool   `json:"welcome"`
+	Welcome        bool   `json:"welcome"` // Deprecated: اب Greetings (صرف مائیگریشن کے لیے)
+	Greetings      map[string]*GreetingTemplate `bson:"greetings" json:"greetings"` // welcome, bye, kick, promote, demote
+	Rules          []string          `bson:"rules" json:"rules"` // نمبر وار گروپ رولز (.warn rule 3)
+	RulesOnJoin    string            `bson:"rules_on_join" json:"rules_on_join"` // "" / dm / group
+	InviteRotation InviteRotationRule `bson:"invite_rotation" json:"invite_rotation"`
+	JoinApproval   JoinApprovalRules `bson:"join_approval" json:"join_approval"`
+}
+
+// JoinApprovalRules پینڈنگ جوائن ریکویسٹس کے آٹو فیصلے کے اصول
+type JoinApprovalRules struct {
+	Enabled      bool     `bson:"enabled" json:"enabled"`
+	AllowedCodes []string `bson:"allowed_codes" json:"allowed_codes"` // خالی = تمام ممالک
+	BlockedCodes []string `bson:"blocked_codes" json:"blocked_codes"`
+	DailyCap     int      `bson:"daily_cap" json:"daily_cap"` // 0 = کوئی حد نہیں
+	CheckBans    bool     `bson:"check_bans" json:"check_bans"`
+}
+
+// GreetingTemplate ایک ایونٹ کا ٹیمپلیٹ (خالی Text = ڈیفالٹ کارڈ)
+type GreetingTemplate struct {
+	Enabled bool   `bson:"enabled" json:"enabled"`
+	Text    string `bson:"text" json:"text"`
+	Image   string `bson:"image" json:"image"` // "" / user / group / custom
+}
+
+// MediaRule ایک میڈیا ٹائپ کا رول (فعال، ایڈمن چھوٹ، ایکشن، وجہ)
+type MediaRule struct {
+	Enabled     bool   `bson:"enabled" json:"enabled"`
+	AdminBypass bool   `bson:"admin_bypass" json:"admin_bypass"`
+	Action      string `bson:"action" json:"action"` // delete / deletekick / deletewarn
+	Reason      string `bson:"reason" json:"reason"`
+}
+
+// AntiForwardRule فارورڈ شدہ میسجز کا رول (MinScore 0 = ہر فارورڈ)
+type AntiForwardRule struct {
+	Enabled     bool   `bson:"enabled" json:"enabled"`
+	AdminBypass bool   `bson:"admin_bypass" json:"admin_bypass"`
+	Action      string `bson:"action" json:"action"`
+	MinScore    int    `bson:"min_score" json:"min_score"` // WhatsApp "forwarded many times" = 5+
+}
+
+// AntiRaidRule جوائن برسٹ پر آٹو لاک ڈاؤن (0 = ڈیفالٹ ویلیو)
+type AntiRaidRule struct {
+	Enabled     bool `bson:"enabled" json:"enabled"`
+	Threshold   int  `bson:"threshold" json:"threshold"`       // اتنے جوائنز
+	WindowSec   int  `bson:"window_sec" json:"window_sec"`     // اتنے سیکنڈز میں
+	KickJoiners bool `bson:"kick_joiners" json:"kick_joiners"` // برسٹ والوں کو نکالیں
+	CooldownMin int  `bson:"cooldown_min" json:"cooldown_min"` // لاک ڈاؤن کتنی دیر
+}
+
+// InviteRotationRule انوائٹ لنک کی خودکار تبدیلی (0 = بند)
+type InviteRotationRule struct {
+	EveryHours int `bson:"every_hours" json:"every_hours"` // اتنے گھنٹے بعد نیا لنک
+	AfterJoins int `bson:"after_joins" json:"after_joins"` // لنک سے اتنے جوائنز کے بعد
+}
+
+// BannedMediaRule .banmedia بلاک لسٹ کا ایکشن (MaxDistance 0 = ڈیفالٹ، -1 = صرف بالکل ایک جیسا)
+type BannedMediaRule struct {
+	Action      string `bson:"action" json:"action"`
+	MaxDistance int    `bson:"max_distance" json:"max_distance"`
+}
+
+// AdminGuardRule غیر مجاز پروموٹ/ڈیموٹ واپس پلٹنے کا رول
+type AdminGuardRule struct {
+	Enabled   bool     `bson:"enabled" json:"enabled"`
+	Protected []string `bson:"protected" json:"protected"` // یہ ایڈمنز تبدیلی کر سکتے ہیں (صاف آئی ڈیز)
 }
 // ✅ نام کو TikTokState سے بدل کر TTState کر دیا گیا ہے
 type TTState struct {
@@ -71,6 +145,7 @@ type SetupState struct {
 	User     string // کون سا ایڈمن سیٹ اپ کر رہا ہے
 	BotLID   string // کس بوٹ کے ذریعے سیٹ اپ ہو رہا ہے (Multi-Bot Fix)
 	BotMsgID string // بوٹ کے بھیجے گئے کارڈ کی یونیک آئی ڈی (Reply Check)
+	Answers  map[string]string // پچھلے اسٹیپس کے جوابات (Step Key → Value)
 }
 
 // --- 🌍 GLOBAL VARIABLES ---
@@ -79,4 +154,5 @@ var (
 	data       BotData
 	dataMutex  sync.RWMutex
 	setupMap   = make(map[string]*SetupState)
+	setupMutex sync.Mutex
 )
\ No newline at end of file
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ==================== سیٹ اپ وزرڈ انجن ====================
+// ہر فیچر اپنے اسٹیپس، چوائسز، ویلیڈیشن اور فائنل Apply خود ڈیفائن کرتا ہے
+// سیشن setupMap میں بوٹ کے کارڈ کی ID سے محفوظ ہوتا ہے، یوزر اسی کارڈ کو ریپلائی کرتا ہے
+
+const defaultWizardTimeout = 2 * time.Minute
+
+// WizardChoice ایک نمبر والا آپشن
+type WizardChoice struct {
+	Label string // کارڈ پر دکھنے والا متن
+	Value string // Answers میں محفوظ ہونے والی ویلیو
+}
+
+// WizardStep وزرڈ کا ایک سوال
+// Choices ہوں تو یوزر نمبر بھیجے گا، ورنہ Validate فری ٹیکسٹ چیک کرے گا
+type WizardStep struct {
+	Key      string
+	Question string
+	Choices  []WizardChoice
+	Validate func(input string) (string, error)
+}
+
+// WizardDef مکمل وزرڈ کی تعریف
+type WizardDef struct {
+	Name    string
+	Title   string // جیسے "🛡️ ANTILINK SETUP"
+	Steps   []WizardStep
+	Timeout time.Duration
+	// سب اسٹیپس مکمل ہونے پر، واپسی پر فائنل کارڈ کا متن
+	Apply func(client *whatsmeow.Client, state *SetupState) (string, error)
+}
+
+var wizardRegistry = make(map[string]*WizardDef)
+
+func registerWizard(def *WizardDef) {
+	wizardRegistry[def.Name] = def
+}
+
+var wizardNumberEmojis = []string{"1️⃣", "2️⃣", "3️⃣", "4️⃣", "5️⃣", "6️⃣", "7️⃣", "8️⃣", "9️⃣"}
+
+// 🧾 اسٹیپ کا کارڈ
+func renderWizardStep(def *WizardDef, idx int) string {
+	step := def.Steps[idx]
+	out := fmt.Sprintf("╔════════════════╗\n║ %s (%d/%d)\n╠════════════════╣\n║ %s\n",
+		def.Title, idx+1, len(def.Steps), step.Question)
+	for i, c := range step.Choices {
+		num := fmt.Sprintf("%d.", i+1)
+		if i < len(wizardNumberEmojis) {
+			num = wizardNumberEmojis[i]
+		}
+		out += fmt.Sprintf("║ %s %s\n", num, c.Label)
+	}
+	out += "╠════════════════╣\n║ ↩️ Reply to this card\n║ ❌ Send 'cancel' to abort\n╚════════════════╝"
+	return out
+}
+
+// 🚀 وزرڈ شروع کریں
+func startWizardFlow(client *whatsmeow.Client, v *events.Message, name, groupID string) {
+	def, ok := wizardRegistry[name]
+	if !ok || len(def.Steps) == 0 {
+		replyMessage(client, v, "⚠️ Unknown setup: "+name)
+		return
+	}
+	state := &SetupState{
+		Type:    name,
+		Stage:   1,
+		GroupID: groupID,
+		User:    v.Info.Sender.User,
+		BotLID:  getCleanID(client.Store.ID.User),
+		Answers: make(map[string]string),
+	}
+	sendWizardStep(client, v, def, state)
+}
+
+// اسٹیپ بھیجیں اور سیشن کو نئے کارڈ کی ID پر محفوظ کریں
+func sendWizardStep(client *whatsmeow.Client, v *events.Message, def *WizardDef, state *SetupState) {
+	resp, err := client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{Text: proto.String(renderWizardStep(def, state.Stage-1))},
+	})
+	if err != nil {
+		fmt.Printf("❌ [WIZARD] Failed to send step %d of %s: %v\n", state.Stage, def.Name, err)
+		return
+	}
+
+	state.BotMsgID = resp.ID
+	setupMutex.Lock()
+	setupMap[resp.ID] = state
+	setupMutex.Unlock()
+
+	timeout := def.Timeout
+	if timeout == 0 {
+		timeout = defaultWizardTimeout
+	}
+	chat := v.Info.Chat
+	go func(key string) {
+		time.Sleep(timeout)
+		setupMutex.Lock()
+		_, pending := setupMap[key]
+		delete(setupMap, key)
+		setupMutex.Unlock()
+		if pending {
+			client.SendMessage(context.Background(), chat, &waProto.Message{
+				ExtendedTextMessage: &waProto.ExtendedTextMessage{
+					Text:        proto.String(fmt.Sprintf("⌛ %s timed out. Run the command again.", def.Title)),
+					ContextInfo: &waProto.ContextInfo{StanzaID: proto.String(key)},
+				},
+			})
+		}
+	}(resp.ID)
+}
+
+// کیا یہ میسج کسی فعال وزرڈ کارڈ کا ریپلائی ہے؟
+func hasSetupSession(quotedID string) bool {
+	setupMutex.Lock()
+	defer setupMutex.Unlock()
+	_, ok := setupMap[quotedID]
+	return ok
+}
+
+// 📩 وزرڈ کارڈ پر آنے والا جواب
+func handleSetupResponse(client *whatsmeow.Client, v *events.Message) {
+	extMsg := v.Message.GetExtendedTextMessage()
+	if extMsg == nil || extMsg.ContextInfo == nil {
+		return
+	}
+	quotedID := extMsg.ContextInfo.GetStanzaID()
+	botID := getCleanID(client.Store.ID.User)
+
+	setupMutex.Lock()
+	state, exists := setupMap[quotedID]
+	setupMutex.Unlock()
+	if !exists || state.BotLID != botID {
+		return // یہ سیشن اس بوٹ کا نہیں ہے
+	}
+	if state.User != v.Info.Sender.User {
+		fmt.Println("🚫 [WIZARD] User mismatch in setup.")
+		return
+	}
+
+	def, ok := wizardRegistry[state.Type]
+	if !ok {
+		setupMutex.Lock()
+		delete(setupMap, quotedID)
+		setupMutex.Unlock()
+		return
+	}
+
+	txt := strings.TrimSpace(getText(v.Message))
+
+	// ❌ کینسل
+	if lower := strings.ToLower(txt); lower == "cancel" || lower == "exit" {
+		setupMutex.Lock()
+		delete(setupMap, quotedID)
+		setupMutex.Unlock()
+		replyMessage(client, v, fmt.Sprintf("❌ %s cancelled.", def.Title))
+		return
+	}
+
+	// ✅ جواب کی جانچ
+	step := def.Steps[state.Stage-1]
+	var value string
+	if len(step.Choices) > 0 {
+		idx, err := strconv.Atoi(txt)
+		if err != nil || idx < 1 || idx > len(step.Choices) {
+			nums := make([]string, len(step.Choices))
+			for i := range step.Choices {
+				nums[i] = fmt.Sprint(i + 1)
+			}
+			replyMessage(client, v, "⚠️ Please reply with "+strings.Join(nums, ", "))
+			return
+		}
+		value = step.Choices[idx-1].Value
+	} else {
+		value = txt
+		if step.Validate != nil {
+			var err error
+			if value, err = step.Validate(txt); err != nil {
+				replyMessage(client, v, "⚠️ "+err.Error())
+				return
+			}
+		}
+	}
+
+	// پرانا سیشن ختم (نیا کارڈ نئی ID پر ہوگا)
+	setupMutex.Lock()
+	delete(setupMap, quotedID)
+	setupMutex.Unlock()
+
+	state.Answers[step.Key] = value
+
+	// ⏭️ اگلا اسٹیپ
+	if state.Stage < len(def.Steps) {
+		state.Stage++
+		sendWizardStep(client, v, def, state)
+		return
+	}
+
+	// 🏁 فائنل
+	finalMsg, err := def.Apply(client, state)
+	if err != nil {
+		replyMessage(client, v, "❌ Setup failed: "+err.Error())
+		return
+	}
+	replyMessage(client, v, finalMsg)
+	fmt.Printf("🏁 [WIZARD] %s completed on Bot %s\n", def.Name, botID)
+}